@@ -5,12 +5,14 @@ import (
 	"log"
 	"net/http"
 	"regexp"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/go-playground/validator/v10/non-standard/validators"
 	"github.com/joho/godotenv"
 	"github.com/omareloui/formmap"
 	"github.com/omareloui/odinls/config"
+	"github.com/omareloui/odinls/internal/adapters/notifier"
 	"github.com/omareloui/odinls/internal/api/handler"
 	"github.com/omareloui/odinls/internal/api/router"
 	application "github.com/omareloui/odinls/internal/application/core"
@@ -41,7 +43,13 @@ func main() {
 	validator := formmap.NewValidator()
 	sanitizer := conformadaptor.NewSanitizer()
 
-	app := application.NewApplication(repo, validator, sanitizer)
+	app := application.NewApplication(repo, validator, sanitizer, notifier.NewLogNotifier(), config.GetReorderMaxLeadDays())
+
+	go runDaily(config.GetReorderDigestHour(), func() {
+		if err := app.ReorderService.SendDigest(); err != nil {
+			logger.Get().Error("error sending the reorder digest", zap.Error(err))
+		}
+	})
 
 	_ = validator.RegisterValidation("not_blank", validators.NotBlank)
 	_ = validator.RegisterValidation("alphanum_with_underscore", IsAlphaNumWithUnderScore)
@@ -59,6 +67,19 @@ func main() {
 	log.Fatalln(srv.ListenAndServe())
 }
 
+// runDaily runs the job every day at the given hour.
+func runDaily(hour int, job func()) {
+	for {
+		now := time.Now()
+		next := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, now.Location())
+		if !next.After(now) {
+			next = next.AddDate(0, 0, 1)
+		}
+		time.Sleep(time.Until(next))
+		job()
+	}
+}
+
 func IsAlphaNumWithUnderScore(fl validator.FieldLevel) bool {
 	re := regexp.MustCompile(`^[A-Za-z0-9_]+$`)
 	field := fl.Field()
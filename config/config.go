@@ -29,6 +29,17 @@ func GetMongoCred() options.Credential {
 	}
 }
 
+// GetReorderDigestHour is the hour of the day to send the reorder digest at.
+func GetReorderDigestHour() int {
+	return getEnvironmentIntWithDefault("REORDER_DIGEST_HOUR", 8)
+}
+
+// GetReorderMaxLeadDays is the most days to wait for a supplier's delivery
+// when suggesting who to reorder from.
+func GetReorderMaxLeadDays() int {
+	return getEnvironmentIntWithDefault("REORDER_MAX_LEAD_DAYS", 14)
+}
+
 func GetLogLevel() int {
 	return getEnvironmentIntWithDefault("LOG_LEVEL", 0)
 }
@@ -0,0 +1,20 @@
+// Package notifier has the ways to send the app's notifications.
+package notifier
+
+import (
+	"github.com/omareloui/odinls/internal/logger"
+	"go.uber.org/zap"
+)
+
+// LogNotifier writes the notifications to the app's log, it's used when no
+// other notifier is set up.
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Notify(subject, body string) error {
+	logger.Get().Info(subject, zap.String("space", "NOTIFIER"), zap.String("body", body))
+	return nil
+}
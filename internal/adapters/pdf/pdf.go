@@ -0,0 +1,245 @@
+// Package pdf is a minimal PDF writer for simple text documents (like
+// invoices). It embeds the DejaVu Sans fonts, so any text they cover renders,
+// the Arabic text included.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	_ "embed"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+//go:embed fonts/DejaVuSans.ttf
+var regularTTF []byte
+
+//go:embed fonts/DejaVuSans-Bold.ttf
+var boldTTF []byte
+
+var fonts = [...]*ttf{
+	Regular: mustParseTTF(regularTTF),
+	Bold:    mustParseTTF(boldTTF),
+}
+
+var fontNames = [...]string{
+	Regular: "DejaVuSans",
+	Bold:    "DejaVuSans-Bold",
+}
+
+// A4 page size in points.
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+type Font int
+
+const (
+	Regular Font = iota
+	Bold
+)
+
+func (f Font) resource() string {
+	if f == Bold {
+		return "F2"
+	}
+	return "F1"
+}
+
+type Document struct {
+	pages []*Page
+	// used is the glyphs each font drew and the runes they're for, only the
+	// used fonts are embedded.
+	used [len(fonts)]map[uint16]rune
+}
+
+type Page struct {
+	doc     *Document
+	content bytes.Buffer
+}
+
+func New() *Document {
+	return &Document{}
+}
+
+func (d *Document) AddPage() *Page {
+	p := &Page{doc: d}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// Text writes the text with its baseline at y, both x and y are measured from
+// the top left corner of the page.
+func (p *Page) Text(x, y, size float64, font Font, text string) {
+	if p.doc.used[font] == nil {
+		p.doc.used[font] = map[uint16]rune{}
+	}
+
+	var hex strings.Builder
+	for _, r := range shape(text) {
+		g := fonts[font].glyph(r)
+		p.doc.used[font][g] = r
+		fmt.Fprintf(&hex, "%04X", g)
+	}
+
+	fmt.Fprintf(&p.content, "BT /%s %.2f Tf %.2f %.2f Td <%s> Tj ET\n",
+		font.resource(), size, x, PageHeight-y, hex.String())
+}
+
+// TextRight writes the text so it ends at x.
+func (p *Page) TextRight(x, y, size float64, font Font, text string) {
+	p.Text(x-TextWidth(text, size, font), y, size, font, text)
+}
+
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%.2f w %.2f %.2f m %.2f %.2f l S\n",
+		width, x1, PageHeight-y1, x2, PageHeight-y2)
+}
+
+func TextWidth(text string, size float64, font Font) float64 {
+	var w int
+	for _, r := range shape(text) {
+		w += fonts[font].width(fonts[font].glyph(r))
+	}
+	return float64(w) * size / 1000
+}
+
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	buf := new(bytes.Buffer)
+	offsets := []int{}
+
+	obj := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	// The catalog and the pages tree come first, then the pages with their
+	// contents, then the five objects of each used font.
+	const firstPageObj = 3
+	fontsObj := firstPageObj + len(d.pages)*2
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObj+i*2)
+	}
+
+	resources := []string{}
+	next := fontsObj
+	for f, used := range d.used {
+		if used != nil {
+			resources = append(resources, fmt.Sprintf("/%s %d 0 R", Font(f).resource(), next))
+			next += 5
+		}
+	}
+
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+
+	for i, page := range d.pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			PageWidth, PageHeight, strings.Join(resources, " "), firstPageObj+i*2+1))
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.content.Len(), page.content.String()))
+	}
+
+	next = fontsObj
+	for f, used := range d.used {
+		if used == nil {
+			continue
+		}
+		if err := writeFont(obj, next, fonts[f], fontNames[f], used); err != nil {
+			return 0, err
+		}
+		next += 5
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.WriteTo(w)
+}
+
+// writeFont writes the font as a composite font whose character codes are
+// the glyph ids, starting at the object number first. It takes five objects:
+// the font, its glyphs' font, its descriptor, its file, and the map of its
+// glyphs back to the text.
+func writeFont(obj func(string), first int, f *ttf, name string, used map[uint16]rune) error {
+	glyphs := slices.Sorted(func(yield func(uint16) bool) {
+		for g := range used {
+			if !yield(g) {
+				return
+			}
+		}
+	})
+
+	var widths strings.Builder
+	for _, g := range glyphs {
+		fmt.Fprintf(&widths, "%d [%d] ", g, f.width(g))
+	}
+
+	scale := func(v int16) int { return int(v) * 1000 / int(f.unitsPerEm) }
+
+	var file bytes.Buffer
+	zw := zlib.NewWriter(&file)
+	if _, err := zw.Write(f.data); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	obj(fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		name, first+1, first+4))
+	obj(fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /CIDToGIDMap /Identity /W [%s] >>",
+		name, first+2, widths.String()))
+	obj(fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		name, scale(f.bbox[0]), scale(f.bbox[1]), scale(f.bbox[2]), scale(f.bbox[3]),
+		scale(f.ascent), scale(f.descent), scale(f.capHeight), first+3))
+	obj(fmt.Sprintf("<< /Length %d /Length1 %d /Filter /FlateDecode >>\nstream\n%s\nendstream", file.Len(), len(f.data), file.String()))
+
+	cmap := toUnicodeCMap(glyphs, used)
+	obj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(cmap), cmap))
+
+	return nil
+}
+
+// toUnicodeCMap maps the glyphs back to their runes, so the text can be
+// copied and searched.
+func toUnicodeCMap(glyphs []uint16, used map[uint16]rune) string {
+	var sb strings.Builder
+	sb.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n")
+	sb.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n")
+	sb.WriteString("/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n")
+	sb.WriteString("1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+
+	// The bfchar blocks are limited to 100 entries each.
+	for chunk := range slices.Chunk(glyphs, 100) {
+		fmt.Fprintf(&sb, "%d beginbfchar\n", len(chunk))
+		for _, g := range chunk {
+			fmt.Fprintf(&sb, "<%04X> <%s>\n", g, utf16Hex(used[g]))
+		}
+		sb.WriteString("endbfchar\n")
+	}
+
+	sb.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	return sb.String()
+}
+
+func utf16Hex(r rune) string {
+	if r < 0x10000 {
+		return fmt.Sprintf("%04X", r)
+	}
+	r -= 0x10000
+	return fmt.Sprintf("%04X%04X", 0xD800+(r>>10), 0xDC00+(r&0x3FF))
+}
@@ -0,0 +1,216 @@
+package pdf
+
+import "unicode"
+
+// arabicForm is the isolated, final, initial, and medial presentation forms
+// of an Arabic letter. The letters that only join the one before them have no
+// initial nor medial forms.
+type arabicForm [4]rune
+
+const (
+	formIsolated = iota
+	formFinal
+	formInitial
+	formMedial
+)
+
+const (
+	arabicLam     = 0x0644
+	arabicTatweel = 0x0640
+)
+
+var arabicForms = map[rune]arabicForm{
+	0x0621: {0xFE80, 0, 0, 0},
+	0x0622: {0xFE81, 0xFE82, 0, 0},
+	0x0623: {0xFE83, 0xFE84, 0, 0},
+	0x0624: {0xFE85, 0xFE86, 0, 0},
+	0x0625: {0xFE87, 0xFE88, 0, 0},
+	0x0626: {0xFE89, 0xFE8A, 0xFE8B, 0xFE8C},
+	0x0627: {0xFE8D, 0xFE8E, 0, 0},
+	0x0628: {0xFE8F, 0xFE90, 0xFE91, 0xFE92},
+	0x0629: {0xFE93, 0xFE94, 0, 0},
+	0x062A: {0xFE95, 0xFE96, 0xFE97, 0xFE98},
+	0x062B: {0xFE99, 0xFE9A, 0xFE9B, 0xFE9C},
+	0x062C: {0xFE9D, 0xFE9E, 0xFE9F, 0xFEA0},
+	0x062D: {0xFEA1, 0xFEA2, 0xFEA3, 0xFEA4},
+	0x062E: {0xFEA5, 0xFEA6, 0xFEA7, 0xFEA8},
+	0x062F: {0xFEA9, 0xFEAA, 0, 0},
+	0x0630: {0xFEAB, 0xFEAC, 0, 0},
+	0x0631: {0xFEAD, 0xFEAE, 0, 0},
+	0x0632: {0xFEAF, 0xFEB0, 0, 0},
+	0x0633: {0xFEB1, 0xFEB2, 0xFEB3, 0xFEB4},
+	0x0634: {0xFEB5, 0xFEB6, 0xFEB7, 0xFEB8},
+	0x0635: {0xFEB9, 0xFEBA, 0xFEBB, 0xFEBC},
+	0x0636: {0xFEBD, 0xFEBE, 0xFEBF, 0xFEC0},
+	0x0637: {0xFEC1, 0xFEC2, 0xFEC3, 0xFEC4},
+	0x0638: {0xFEC5, 0xFEC6, 0xFEC7, 0xFEC8},
+	0x0639: {0xFEC9, 0xFECA, 0xFECB, 0xFECC},
+	0x063A: {0xFECD, 0xFECE, 0xFECF, 0xFED0},
+	0x0641: {0xFED1, 0xFED2, 0xFED3, 0xFED4},
+	0x0642: {0xFED5, 0xFED6, 0xFED7, 0xFED8},
+	0x0643: {0xFED9, 0xFEDA, 0xFEDB, 0xFEDC},
+	0x0644: {0xFEDD, 0xFEDE, 0xFEDF, 0xFEE0},
+	0x0645: {0xFEE1, 0xFEE2, 0xFEE3, 0xFEE4},
+	0x0646: {0xFEE5, 0xFEE6, 0xFEE7, 0xFEE8},
+	0x0647: {0xFEE9, 0xFEEA, 0xFEEB, 0xFEEC},
+	0x0648: {0xFEED, 0xFEEE, 0, 0},
+	0x0649: {0xFEEF, 0xFEF0, 0, 0},
+	0x064A: {0xFEF1, 0xFEF2, 0xFEF3, 0xFEF4},
+}
+
+// lamAlefForms are the isolated and final forms of lam followed by each of
+// the alefs, they're written as one ligature.
+var lamAlefForms = map[rune][2]rune{
+	0x0622: {0xFEF5, 0xFEF6},
+	0x0623: {0xFEF7, 0xFEF8},
+	0x0625: {0xFEF9, 0xFEFA},
+	0x0627: {0xFEFB, 0xFEFC},
+}
+
+// isTransparent reports whether the rune is a mark that letters join across,
+// like the harakat.
+func isTransparent(r rune) bool {
+	return unicode.Is(unicode.Mn, r)
+}
+
+// joinsNext reports whether the rune connects to the letter after it.
+func joinsNext(r rune) bool {
+	if r == arabicTatweel {
+		return true
+	}
+	f, ok := arabicForms[r]
+	return ok && f[formInitial] != 0
+}
+
+// joinsPrev reports whether the rune connects to the letter before it.
+func joinsPrev(r rune) bool {
+	if r == arabicTatweel {
+		return true
+	}
+	f, ok := arabicForms[r]
+	return ok && f[formFinal] != 0
+}
+
+// neighbour is the closest rune from i in the step's direction that isn't a
+// transparent mark, or 0 if there's none.
+func neighbour(text []rune, i, step int) rune {
+	for j := i + step; j >= 0 && j < len(text); j += step {
+		if !isTransparent(text[j]) {
+			return text[j]
+		}
+	}
+	return 0
+}
+
+// shapeArabic replaces the Arabic letters with their presentation forms by
+// how they join their neighbours, the text stays in its logical order.
+func shapeArabic(text []rune) []rune {
+	out := make([]rune, 0, len(text))
+	for i := 0; i < len(text); i++ {
+		r := text[i]
+		f, ok := arabicForms[r]
+		if !ok {
+			out = append(out, r)
+			continue
+		}
+
+		prevJoins := joinsNext(neighbour(text, i, -1)) && joinsPrev(r)
+
+		if r == arabicLam && i+1 < len(text) {
+			if lig, ok := lamAlefForms[text[i+1]]; ok {
+				if prevJoins {
+					out = append(out, lig[1])
+				} else {
+					out = append(out, lig[0])
+				}
+				i++
+				continue
+			}
+		}
+
+		nextJoins := joinsNext(r) && joinsPrev(neighbour(text, i, 1))
+
+		switch {
+		case prevJoins && nextJoins:
+			out = append(out, f[formMedial])
+		case prevJoins:
+			out = append(out, f[formFinal])
+		case nextJoins:
+			out = append(out, f[formInitial])
+		default:
+			out = append(out, f[formIsolated])
+		}
+	}
+	return out
+}
+
+func isRTL(r rune) bool {
+	return unicode.In(r, unicode.Arabic, unicode.Hebrew)
+}
+
+func isLTR(r rune) bool {
+	return unicode.IsLetter(r) && !isRTL(r)
+}
+
+var mirrored = map[rune]rune{'(': ')', ')': '(', '[': ']', ']': '[', '{': '}', '}': '{', '<': '>', '>': '<'}
+
+// visualOrder reorders the right-to-left runs of the text to be drawn from
+// left to right. It's a simplified bidi for lines that are mostly left to
+// right: a run starts at a right-to-left letter and takes the numbers and the
+// neutrals up to the next left-to-right letter, the numbers in it keep their
+// order.
+func visualOrder(text []rune) []rune {
+	out := make([]rune, len(text))
+	copy(out, text)
+
+	for i := 0; i < len(out); {
+		if !isRTL(out[i]) {
+			i++
+			continue
+		}
+
+		end, last := i, i
+		for end < len(out) && !isLTR(out[end]) {
+			if isRTL(out[end]) || unicode.IsDigit(out[end]) {
+				last = end
+			}
+			end++
+		}
+
+		reverseRun(out[i : last+1])
+		i = last + 1
+	}
+	return out
+}
+
+// reverseRun reverses the run and mirrors its brackets, the digits in it are
+// reversed back to read left to right.
+func reverseRun(run []rune) {
+	for l, r := 0, len(run)-1; l < r; l, r = l+1, r-1 {
+		run[l], run[r] = run[r], run[l]
+	}
+	for i, c := range run {
+		if m, ok := mirrored[c]; ok {
+			run[i] = m
+		}
+	}
+	for i := 0; i < len(run); {
+		if !unicode.IsDigit(run[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(run) && (unicode.IsDigit(run[j]) || run[j] == '.' || run[j] == ',') {
+			j++
+		}
+		for l, r := i, j-1; l < r; l, r = l+1, r-1 {
+			run[l], run[r] = run[r], run[l]
+		}
+		i = j
+	}
+}
+
+// shape prepares the text to be drawn glyph by glyph from left to right.
+func shape(text string) []rune {
+	return visualOrder(shapeArabic([]rune(text)))
+}
@@ -0,0 +1,46 @@
+package pdf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShape(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []rune
+	}{
+		{"latin is left as it is", "Wallet (x2)", []rune("Wallet (x2)")},
+		{"joined word", "محمد", []rune{0xFEAA, 0xFEE4, 0xFEA4, 0xFEE3}},
+		{"right joining letter breaks the word", "دار", []rune{0xFEAD, 0xFE8D, 0xFEA9}},
+		{"isolated letter", "ب", []rune{0xFE8F}},
+		{"lam alef ligature", "لا", []rune{0xFEFB}},
+		{"final lam alef ligature", "سلام", []rune{0xFEE1, 0xFEFC, 0xFEB3}},
+		{"words keep their order from the right", "أحمد علي", []rune{0xFEF2, 0xFEE0, 0xFECB, ' ', 0xFEAA, 0xFEE4, 0xFEA3, 0xFE83}},
+		{"numbers in arabic read left to right", "شارع 12", []rune{'1', '2', ' ', 0xFEC9, 0xFEAD, 0xFE8E, 0xFEB7}},
+		{"arabic in a latin line", "Client: علي", []rune{'C', 'l', 'i', 'e', 'n', 't', ':', ' ', 0xFEF2, 0xFEE0, 0xFECB}},
+		{"brackets are mirrored", "(علي)", []rune{'(', 0xFEF2, 0xFEE0, 0xFECB, ')'}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, string(tt.want), string(shape(tt.text)))
+		})
+	}
+}
+
+func TestFontsCoverArabic(t *testing.T) {
+	for _, f := range fonts {
+		for _, form := range arabicForms {
+			for _, r := range form {
+				if r == 0 {
+					continue
+				}
+				_, ok := f.glyphs[r]
+				assert.True(t, ok, "missing glyph for %U", r)
+			}
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package pdf
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var errInvalidFont = errors.New("invalid truetype font")
+
+// ttf is what the writer needs from a TrueType font: its glyph ids, their
+// widths, and the metrics of its descriptor.
+type ttf struct {
+	data []byte
+
+	unitsPerEm uint16
+	ascent     int16
+	descent    int16
+	capHeight  int16
+	bbox       [4]int16
+
+	advances []uint16
+	glyphs   map[rune]uint16
+}
+
+func mustParseTTF(data []byte) *ttf {
+	f, err := parseTTF(data)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func parseTTF(data []byte) (*ttf, error) {
+	if len(data) < 12 {
+		return nil, errInvalidFont
+	}
+
+	tables := map[string][]byte{}
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	for i := range numTables {
+		rec := 12 + i*16
+		if rec+16 > len(data) {
+			return nil, errInvalidFont
+		}
+		tag := string(data[rec : rec+4])
+		off := int(binary.BigEndian.Uint32(data[rec+8:]))
+		length := int(binary.BigEndian.Uint32(data[rec+12:]))
+		if off+length > len(data) {
+			return nil, errInvalidFont
+		}
+		tables[tag] = data[off : off+length]
+	}
+
+	head, hhea, maxp, hmtx, cmap := tables["head"], tables["hhea"], tables["maxp"], tables["hmtx"], tables["cmap"]
+	if len(head) < 54 || len(hhea) < 36 || len(maxp) < 6 || hmtx == nil || cmap == nil {
+		return nil, errInvalidFont
+	}
+
+	f := &ttf{
+		data:       data,
+		unitsPerEm: binary.BigEndian.Uint16(head[18:]),
+		ascent:     int16(binary.BigEndian.Uint16(hhea[4:])),
+		descent:    int16(binary.BigEndian.Uint16(hhea[6:])),
+	}
+	for i := range f.bbox {
+		f.bbox[i] = int16(binary.BigEndian.Uint16(head[36+i*2:]))
+	}
+	f.capHeight = f.ascent
+	if os2 := tables["OS/2"]; len(os2) >= 90 && binary.BigEndian.Uint16(os2) >= 2 {
+		f.capHeight = int16(binary.BigEndian.Uint16(os2[88:]))
+	}
+
+	numGlyphs := int(binary.BigEndian.Uint16(maxp[4:]))
+	numMetrics := int(binary.BigEndian.Uint16(hhea[34:]))
+	if numMetrics == 0 || numMetrics > numGlyphs || len(hmtx) < numMetrics*4 {
+		return nil, errInvalidFont
+	}
+	f.advances = make([]uint16, numGlyphs)
+	for i := range f.advances {
+		if i < numMetrics {
+			f.advances[i] = binary.BigEndian.Uint16(hmtx[i*4:])
+		} else {
+			f.advances[i] = f.advances[numMetrics-1]
+		}
+	}
+
+	glyphs, err := parseCmap(cmap)
+	if err != nil {
+		return nil, err
+	}
+	f.glyphs = glyphs
+
+	return f, nil
+}
+
+// parseCmap reads the unicode mapping of the font, the full repertoire one
+// (format 12) if it's there, or the basic plane one (format 4).
+func parseCmap(cmap []byte) (map[rune]uint16, error) {
+	if len(cmap) < 4 {
+		return nil, errInvalidFont
+	}
+
+	var format4, format12 []byte
+	numTables := int(binary.BigEndian.Uint16(cmap[2:]))
+	for i := range numTables {
+		rec := 4 + i*8
+		if rec+8 > len(cmap) {
+			return nil, errInvalidFont
+		}
+		platform, encoding := binary.BigEndian.Uint16(cmap[rec:]), binary.BigEndian.Uint16(cmap[rec+2:])
+		off := int(binary.BigEndian.Uint32(cmap[rec+4:]))
+		if off+2 > len(cmap) {
+			return nil, errInvalidFont
+		}
+		switch {
+		case platform == 3 && encoding == 10 && binary.BigEndian.Uint16(cmap[off:]) == 12:
+			format12 = cmap[off:]
+		case platform == 3 && encoding == 1 && binary.BigEndian.Uint16(cmap[off:]) == 4:
+			format4 = cmap[off:]
+		}
+	}
+
+	switch {
+	case format12 != nil:
+		return parseCmapFormat12(format12)
+	case format4 != nil:
+		return parseCmapFormat4(format4)
+	}
+	return nil, errInvalidFont
+}
+
+func parseCmapFormat4(sub []byte) (map[rune]uint16, error) {
+	if len(sub) < 14 {
+		return nil, errInvalidFont
+	}
+	segCount := int(binary.BigEndian.Uint16(sub[6:])) / 2
+	endCodes := 14
+	startCodes := endCodes + segCount*2 + 2
+	deltas := startCodes + segCount*2
+	rangeOffsets := deltas + segCount*2
+	if rangeOffsets+segCount*2 > len(sub) {
+		return nil, errInvalidFont
+	}
+
+	glyphs := map[rune]uint16{}
+	for i := range segCount {
+		end := int(binary.BigEndian.Uint16(sub[endCodes+i*2:]))
+		start := int(binary.BigEndian.Uint16(sub[startCodes+i*2:]))
+		delta := binary.BigEndian.Uint16(sub[deltas+i*2:])
+		rangeOffset := int(binary.BigEndian.Uint16(sub[rangeOffsets+i*2:]))
+
+		for c := start; c <= end && c != 0xFFFF; c++ {
+			var g uint16
+			if rangeOffset == 0 {
+				g = uint16(c) + delta
+			} else {
+				at := rangeOffsets + i*2 + rangeOffset + (c-start)*2
+				if at+2 > len(sub) {
+					continue
+				}
+				if g = binary.BigEndian.Uint16(sub[at:]); g != 0 {
+					g += delta
+				}
+			}
+			if g != 0 {
+				glyphs[rune(c)] = g
+			}
+		}
+	}
+	return glyphs, nil
+}
+
+func parseCmapFormat12(sub []byte) (map[rune]uint16, error) {
+	if len(sub) < 16 {
+		return nil, errInvalidFont
+	}
+	numGroups := int(binary.BigEndian.Uint32(sub[12:]))
+	if 16+numGroups*12 > len(sub) {
+		return nil, errInvalidFont
+	}
+
+	glyphs := map[rune]uint16{}
+	for i := range numGroups {
+		group := sub[16+i*12:]
+		start, end := binary.BigEndian.Uint32(group), binary.BigEndian.Uint32(group[4:])
+		g := binary.BigEndian.Uint32(group[8:])
+		for c := start; c <= end; c++ {
+			glyphs[rune(c)] = uint16(g + c - start)
+		}
+	}
+	return glyphs, nil
+}
+
+// glyph is the glyph id of the rune, the missing ones are drawn as "?".
+func (f *ttf) glyph(r rune) uint16 {
+	if g, ok := f.glyphs[r]; ok {
+		return g
+	}
+	return f.glyphs['?']
+}
+
+// width is the glyph's advance in thousandths of the font size.
+func (f *ttf) width(g uint16) int {
+	if int(g) >= len(f.advances) {
+		return 0
+	}
+	return int(f.advances[g]) * 1000 / int(f.unitsPerEm)
+}
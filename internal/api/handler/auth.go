@@ -76,7 +76,7 @@ func (h *handler) Register(w http.ResponseWriter, r *http.Request) (templ.Compon
 	http.SetCookie(w, cookiesPair.Access)
 	http.SetCookie(w, cookiesPair.Refresh)
 
-	return responder.RedirectHX(w, responder.WithPath("/"))
+	return responder.RedirectHX(w, responder.WithPath(landingPath(usr)))
 }
 
 func (h *handler) RefreshTokens(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
@@ -136,7 +136,7 @@ func (h *handler) Login(w http.ResponseWriter, r *http.Request) (templ.Component
 	http.SetCookie(w, cookiesPair.Refresh)
 	http.SetCookie(w, cookiesPair.Access)
 
-	return responder.RedirectHX(w, responder.WithPath("/"))
+	return responder.RedirectHX(w, responder.WithPath(landingPath(usr)))
 }
 
 func (h *handler) Logout(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
@@ -146,6 +146,15 @@ func (h *handler) Logout(w http.ResponseWriter, r *http.Request) (templ.Componen
 	return responder.RedirectHX(w, responder.WithPath("/"))
 }
 
+// landingPath is where the user goes after logging in, the users with no
+// authority are the clients so they go to their portal.
+func landingPath(usr *user.User) string {
+	if usr.Role.IsModerator() || usr.IsCraftsman() {
+		return "/"
+	}
+	return "/me"
+}
+
 func (h *handler) newCookiesPairFromUser(usr *user.User) (*cookiePair, error) {
 	tokens, err := jwtadapter.NewPair(usr)
 	if err != nil {
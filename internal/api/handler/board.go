@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/a-h/templ"
+	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+	"github.com/omareloui/odinls/internal/api/responder"
+	"github.com/omareloui/odinls/internal/application/core/material"
+	"github.com/omareloui/odinls/internal/application/core/order"
+	"github.com/omareloui/odinls/internal/application/core/user"
+	"github.com/omareloui/odinls/web/views"
+)
+
+func (h *handler) GetBoard(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	board, craftsmen, hides, err := h.getBoardData(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.BoardPage(claims, board, craftsmen, hides)))
+}
+
+func (h *handler) UpdateItemProgress(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	orderID := r.PathValue("id")
+	itemID := r.PathValue("itemId")
+	claims := getClaims(r.Context())
+
+	progress := order.ItemProgressEnum(r.FormValue("progress"))
+
+	_, err := h.app.OrderService.UpdateItemProgress(claims, orderID, itemID, progress)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	board, craftsmen, hides, err := h.getBoardData(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.Board(claims, board, craftsmen, hides)))
+}
+
+func (h *handler) AssignItemCraftsman(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	orderID := r.PathValue("id")
+	itemID := r.PathValue("itemId")
+	claims := getClaims(r.Context())
+
+	_, err := h.app.OrderService.AssignItemCraftsman(claims, orderID, itemID, r.FormValue("craftsman"))
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	board, craftsmen, hides, err := h.getBoardData(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.Board(claims, board, craftsmen, hides)))
+}
+
+func (h *handler) PickItemHides(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	orderID := r.PathValue("id")
+	itemID := r.PathValue("itemId")
+	claims := getClaims(r.Context())
+
+	if err := r.ParseForm(); err != nil {
+		return responder.BadRequest()
+	}
+
+	hideIDs := []string{}
+	for _, id := range r.Form["hide"] {
+		if id != "" {
+			hideIDs = append(hideIDs, id)
+		}
+	}
+
+	_, err := h.app.OrderService.PickItemHides(claims, orderID, itemID, hideIDs)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	board, craftsmen, hides, err := h.getBoardData(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.Board(claims, board, craftsmen, hides)))
+}
+
+// getBoardData gets the board, the hides to cut the items from, and the
+// craftsmen to assign the items to if the user is an admin.
+func (h *handler) getBoardData(claims *jwtadapter.AccessClaims) (*order.Board, []user.User, []material.Hide, error) {
+	board, err := h.app.OrderService.GetBoard(claims)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	hides, err := h.app.MaterialService.GetHides(claims, "")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if !claims.Role.IsAdmin() {
+		return board, nil, hides, nil
+	}
+
+	craftsmen, err := h.getCraftsmen()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return board, craftsmen, hides, nil
+}
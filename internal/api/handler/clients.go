@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"cmp"
 	"net/http"
+	"slices"
 
 	"github.com/a-h/templ"
 	"github.com/omareloui/former"
@@ -18,7 +20,22 @@ func (h *handler) GetClients(w http.ResponseWriter, r *http.Request) (templ.Comp
 		return responder.Error(err)
 	}
 
-	comp := views.ClientsPage(claims, clients, &views.ClientFormData{})
+	sortBy := r.URL.Query().Get("sort")
+
+	var values map[string]float64
+	if claims.Role.IsModerator() {
+		values, err = h.app.OrderService.GetClientsLifetimeValues(claims)
+		if err != nil {
+			return responder.Error(err)
+		}
+		if sortBy == views.SortByLifetimeValue {
+			slices.SortStableFunc(clients, func(a, b client.Client) int {
+				return cmp.Compare(values[b.ID], values[a.ID])
+			})
+		}
+	}
+
+	comp := views.ClientsPage(claims, clients, values, sortBy, &views.ClientFormData{})
 	return responder.OK(responder.WithComponent(comp))
 }
 
@@ -49,7 +66,23 @@ func (h *handler) GetClient(w http.ResponseWriter, r *http.Request) (templ.Compo
 	if err != nil {
 		return responder.Error(err)
 	}
-	return responder.OK(responder.WithComponent(views.Client(c)))
+
+	// HTMX gets the client's entry back, like when canceling the edit.
+	if isHXRequest(r) {
+		return responder.OK(responder.WithComponent(views.Client(c)))
+	}
+
+	history, err := h.app.OrderService.GetClientHistory(claims, id)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	merges, err := h.app.ClientService.GetClientMerges(claims, id)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.ClientPage(claims, c, history, merges)))
 }
 
 func (h *handler) GetEditClient(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
@@ -86,3 +119,31 @@ func (h *handler) EditClient(w http.ResponseWriter, r *http.Request) (templ.Comp
 
 	return responder.OK(responder.WithComponent(views.Client(cli)))
 }
+
+func (h *handler) GetClientDuplicates(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	duplicates, err := h.app.ClientService.GetDuplicateClients(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.ClientDuplicatesPage(claims, duplicates)))
+}
+
+func (h *handler) MergeClients(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	_, err := h.app.ClientService.MergeClients(claims, id, r.FormValue("duplicate_id"))
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	duplicates, err := h.app.ClientService.GetDuplicateClients(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.ClientDuplicates(claims, duplicates)))
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/a-h/templ"
+	"github.com/omareloui/former"
+	"github.com/omareloui/odinls/internal/api/responder"
+	"github.com/omareloui/odinls/internal/application/core/costing"
+	"github.com/omareloui/odinls/internal/errs"
+	"github.com/omareloui/odinls/web/views"
+)
+
+func (h *handler) GetCostingSettings(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+	if claims == nil || !claims.Role.IsAdmin() {
+		return responder.Error(errs.ErrForbidden)
+	}
+
+	settings, err := h.app.CostingService.GetSettings(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	fd := new(views.CostingSettingsFormData)
+	h.fm.MapToForm(settings, nil, fd)
+
+	return responder.OK(responder.WithComponent(views.CostingSettingsPage(claims, fd)))
+}
+
+func (h *handler) EditCostingSettings(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	settings := new(costing.Settings)
+	err := former.Populate(r, settings)
+	if err != nil {
+		return responder.BadRequest()
+	}
+
+	saved, err := h.app.CostingService.UpdateSettings(claims, settings)
+	if err != nil {
+		fd := new(views.CostingSettingsFormData)
+		h.fm.MapToForm(settings, err, fd)
+		return responder.Error(err,
+			responder.WithComponentIfValidationErr(views.CostingSettingsForm(fd, false)))
+	}
+
+	fd := new(views.CostingSettingsFormData)
+	h.fm.MapToForm(saved, nil, fd)
+	return responder.OK(responder.WithComponent(views.CostingSettingsForm(fd, true)))
+}
@@ -33,30 +33,96 @@ type Handler interface {
 	GetClient(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 	GetEditClient(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 	EditClient(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetClientDuplicates(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	MergeClients(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 
 	GetSuppliers(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 	CreateSupplier(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 	GetSupplier(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 	GetEditSupplier(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 	EditSupplier(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetSupplierPurchaseOrders(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetSupplierPrices(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	SetSupplierPrice(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	RemoveSupplierPrice(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetSupplierScorecard(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetSupplierScorecardCSV(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+
+	GetPurchaseOrders(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	CreatePurchaseOrder(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetPurchaseOrder(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	TransitionPurchaseOrderStatus(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetReceiveGoods(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	ReceiveGoods(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 
 	GetProducts(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 	CreateProduct(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 	GetProduct(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 	GetEditProduct(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 	EditProduct(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetVariantTierPrices(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	SetVariantTierPrices(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetPriceReviews(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetCapacities(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetShortfalls(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 
 	GetOrders(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 	CreateOrder(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 	GetOrder(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 	GetEditOrder(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 	EditOrder(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	TransitionOrderStatus(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	PromoteCustomItem(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	UpdateItemProgress(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	AssignItemCraftsman(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	PickItemHides(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+
+	GetBoard(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+
+	GetCostingSettings(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	EditCostingSettings(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetOrderInvoice(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetOrderInvoicePDF(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetOrderPayments(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	RecordOrderPayment(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	RecordOrderRefund(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetPaymentReceipt(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	SetOrderPaymentPlan(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetReceivables(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+
+	GetQuotes(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	CreateQuote(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetQuote(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetEditQuote(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	EditQuote(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	TransitionQuoteStatus(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	ConvertQuote(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetQuoteDocument(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+
+	GetMe(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetMyContact(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetEditMyContact(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	EditMyContact(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetMyOrder(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetMyOrderInvoice(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetMyOrderInvoicePDF(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetMyPaymentReceipt(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+
+	GetTrackOrder(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	TrackOrder(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 
 	GetMaterials(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 	CreateMaterial(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 	GetMaterial(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 	GetEditMaterial(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 	EditMaterial(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetMaterialMovements(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	CreateMaterialMovement(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetMaterialsCosting(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetMaterialHides(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	CreateMaterialHide(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	RecordHideScrap(w http.ResponseWriter, r *http.Request) (templ.Component, error)
+	GetMaterialSuppliers(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 
 	Unauthorized(w http.ResponseWriter, r *http.Request) (templ.Component, error)
 	NotFound(w http.ResponseWriter, r *http.Request) (templ.Component, error)
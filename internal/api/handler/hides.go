@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/a-h/templ"
+	"github.com/omareloui/former"
+	"github.com/omareloui/odinls/internal/api/responder"
+	"github.com/omareloui/odinls/internal/application/core/material"
+	"github.com/omareloui/odinls/internal/application/core/stock"
+	"github.com/omareloui/odinls/internal/errs"
+	"github.com/omareloui/odinls/web/views"
+)
+
+func (h *handler) GetMaterialHides(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	mat, hides, movements, err := h.getHidesInventory(r, id)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(
+		views.MaterialHidesPage(claims, mat, hides, movements, new(views.HideFormData))))
+}
+
+func (h *handler) CreateMaterialHide(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	hide := new(material.Hide)
+	err := former.Populate(r, hide)
+	if err != nil {
+		return responder.BadRequest()
+	}
+
+	_, hideErr := h.app.MaterialService.CreateHide(claims, id, hide)
+
+	mat, hides, movements, err := h.getHidesInventory(r, id)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	if hideErr != nil {
+		fd := new(views.HideFormData)
+		h.fm.MapToForm(hide, hideErr, fd)
+		comp := views.HidesInventory(mat, hides, movements, fd)
+		return responder.Error(hideErr,
+			responder.WithComponentIfValidationErr(comp),
+			responder.WithComponentIfErrIs(errs.ErrInvalidEnum, comp))
+	}
+
+	return responder.Created(responder.WithComponent(
+		views.HidesInventory(mat, hides, movements, new(views.HideFormData))))
+}
+
+func (h *handler) RecordHideScrap(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	area, err := strconv.ParseFloat(r.FormValue("area"), 64)
+	if err != nil {
+		return responder.Error(errs.ErrInvalidFloat)
+	}
+
+	_, err = h.app.StockService.RecordHideScrap(claims, id, r.PathValue("hideId"), area)
+	if err != nil {
+		if errors.Is(err, errs.ErrInsufficientStock) {
+			return responder.Error(err, responder.WithMessage("The scrap can't be bigger than what's left of the hide."))
+		}
+		return responder.Error(err)
+	}
+
+	mat, hides, movements, err := h.getHidesInventory(r, id)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.Created(responder.WithComponent(
+		views.HidesInventory(mat, hides, movements, new(views.HideFormData))))
+}
+
+// getHidesInventory gets the material, its hides, and its stock movements to
+// trace what each hide was used for.
+func (h *handler) getHidesInventory(r *http.Request, materialID string) (*material.Material, []material.Hide, []stock.Movement, error) {
+	claims := getClaims(r.Context())
+
+	mat, err := h.app.MaterialService.GetMaterialByID(claims, materialID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	hides, err := h.app.MaterialService.GetHides(claims, materialID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	movements, err := h.app.StockService.GetMaterialMovements(claims, materialID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return mat, hides, movements, nil
+}
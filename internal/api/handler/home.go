@@ -5,11 +5,22 @@ import (
 
 	"github.com/a-h/templ"
 	"github.com/omareloui/odinls/internal/api/responder"
+	"github.com/omareloui/odinls/internal/application/core/reorder"
 	"github.com/omareloui/odinls/web/views"
 )
 
 func (h *handler) GetHomepage(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
 	claims := getClaims(r.Context())
-	comp := views.Homepage(claims)
+
+	var reorders []reorder.SupplierGroup
+	if claims != nil && claims.Role.IsModerator() {
+		var err error
+		reorders, err = h.app.ReorderService.GetSuggestions(claims)
+		if err != nil {
+			return responder.Error(err)
+		}
+	}
+
+	comp := views.Homepage(claims, reorders)
 	return responder.OK(responder.WithComponent(comp))
 }
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/a-h/templ"
+	"github.com/omareloui/odinls/internal/api/responder"
+	"github.com/omareloui/odinls/web/views"
+)
+
+func (h *handler) GetOrderInvoice(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	inv, err := h.app.InvoiceService.GetOrderInvoice(claims, id)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.InvoicePage(inv, fmt.Sprintf("/orders/%s/invoice.pdf", id))))
+}
+
+func (h *handler) GetOrderInvoicePDF(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	inv, err := h.app.InvoiceService.GetOrderInvoice(claims, id)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="invoice-%d.pdf"`, inv.OrderNumber))
+
+	comp := templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		return h.app.InvoiceService.RenderPDF(inv, w)
+	})
+	return responder.OK(responder.WithComponent(comp))
+}
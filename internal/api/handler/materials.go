@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/a-h/templ"
@@ -106,6 +107,10 @@ func (h *handler) EditMaterial(w http.ResponseWriter, r *http.Request) (templ.Co
 	}
 
 	mat, err = h.app.MaterialService.UpdateMaterialByID(claims, id, mat)
+	if pcErr := new(material.PriceChangeError); errors.As(err, &pcErr) {
+		logger.FromCtx(r.Context()).Error("repricing the material's products", zap.Error(err), zap.String("material_id", id))
+		err = nil
+	}
 	if err != nil {
 		suppliers, supErr := h.app.SupplierService.GetSuppliers(claims)
 		if supErr != nil {
@@ -120,3 +125,19 @@ func (h *handler) EditMaterial(w http.ResponseWriter, r *http.Request) (templ.Co
 
 	return responder.OK(responder.WithComponent(views.Material(mat)))
 }
+
+func (h *handler) GetMaterialsCosting(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	costs, err := h.app.MaterialService.GetUnitCosts(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	settings, err := h.app.CostingService.GetSettings(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.MaterialsCostingPage(claims, costs, settings.MaterialCostingMethod)))
+}
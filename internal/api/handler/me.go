@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/a-h/templ"
+	"github.com/omareloui/former"
+	"github.com/omareloui/odinls/internal/api/responder"
+	"github.com/omareloui/odinls/internal/application/core/client"
+	"github.com/omareloui/odinls/internal/application/core/order"
+	"github.com/omareloui/odinls/web/views"
+)
+
+func (h *handler) GetMe(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	cli, err := h.app.ClientService.GetMyClient(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	orders, err := h.app.OrderService.GetMyOrders(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.MyPage(claims, cli, order.NewClientHistory(orders))))
+}
+
+func (h *handler) GetMyContact(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	cli, err := h.app.ClientService.GetMyClient(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.MyContact(cli)))
+}
+
+func (h *handler) GetEditMyContact(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	cli, err := h.app.ClientService.GetMyClient(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	contact := cli.PrimaryContact()
+	fd := new(views.MyContactFormData)
+	h.fm.MapToForm(&contact, nil, fd)
+	return responder.OK(responder.WithComponent(views.EditMyContact(fd)))
+}
+
+func (h *handler) EditMyContact(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	contact := new(client.PrimaryContact)
+	if err := former.Populate(r, contact); err != nil {
+		return responder.BadRequest()
+	}
+
+	cli, err := h.app.ClientService.UpdateMyContactInfo(claims, contact)
+	if err != nil {
+		fd := new(views.MyContactFormData)
+		h.fm.MapToForm(contact, err, fd)
+		return responder.Error(err, responder.WithComponentIfValidationErr(views.EditMyContact(fd)))
+	}
+
+	return responder.OK(responder.WithComponent(views.MyContact(cli)))
+}
+
+func (h *handler) GetMyOrder(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	ord, err := h.app.OrderService.GetMyOrder(claims, id)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.MyOrderPage(claims, ord)))
+}
+
+func (h *handler) GetMyOrderInvoice(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	inv, err := h.app.InvoiceService.GetMyOrderInvoice(claims, id)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.InvoicePage(inv, fmt.Sprintf("/me/orders/%s/invoice.pdf", id))))
+}
+
+func (h *handler) GetMyOrderInvoicePDF(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	inv, err := h.app.InvoiceService.GetMyOrderInvoice(claims, id)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="invoice-%d.pdf"`, inv.OrderNumber))
+
+	comp := templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		return h.app.InvoiceService.RenderPDF(inv, w)
+	})
+	return responder.OK(responder.WithComponent(comp))
+}
+
+func (h *handler) GetMyPaymentReceipt(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	rec, err := h.app.InvoiceService.GetMyPaymentReceipt(claims, r.PathValue("id"), r.PathValue("paymentId"))
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.ReceiptPage(rec)))
+}
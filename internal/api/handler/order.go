@@ -1,15 +1,22 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/a-h/templ"
 	"github.com/omareloui/former"
 	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
 	"github.com/omareloui/odinls/internal/api/responder"
 	"github.com/omareloui/odinls/internal/application/core/client"
+	"github.com/omareloui/odinls/internal/application/core/material"
 	"github.com/omareloui/odinls/internal/application/core/order"
 	"github.com/omareloui/odinls/internal/application/core/product"
+	"github.com/omareloui/odinls/internal/errs"
 	"github.com/omareloui/odinls/web/views"
 )
 
@@ -21,11 +28,11 @@ func (h *handler) GetOrders(w http.ResponseWriter, r *http.Request) (templ.Compo
 		return responder.Error(err)
 	}
 
-	prods, clients, err := h.getProdsAndClients(claims)
+	prods, clients, materials, err := h.getOrderFormOptions(claims)
 	if err != nil {
 		return responder.Error(err)
 	}
-	return responder.OK(responder.WithComponent(views.OrdersPage(claims, prods, clients, ords)))
+	return responder.OK(responder.WithComponent(views.OrdersPage(claims, prods, clients, materials, ords)))
 }
 
 func (h *handler) CreateOrder(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
@@ -37,21 +44,30 @@ func (h *handler) CreateOrder(w http.ResponseWriter, r *http.Request) (templ.Com
 		return responder.Error(err)
 	}
 
-	prods, clients, err := h.getProdsAndClients(claims)
+	customItems, err := parseCustomItems(r)
 	if err != nil {
 		return responder.Error(err)
 	}
+	ord.Items = append(ord.Items, customItems...)
 
-	ord, err = h.app.OrderService.CreateOrder(claims, ord)
+	prods, clients, materials, err := h.getOrderFormOptions(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	created, err := h.app.OrderService.CreateOrder(claims, ord)
 	if err != nil {
 		fd := new(views.OrderFormData)
-		h.fm.MapToForm(ord, err, fd)
-		comp := views.CreateOrderForm(ord, prods, clients, fd)
-		return responder.Error(err, responder.WithComponentIfValidationErr(comp))
+		h.mapOrderToForm(ord, err, fd)
+		mapCustomItemUnitError(ord, err, fd)
+		comp := views.CreateOrderForm(ord, prods, clients, materials, fd)
+		return responder.Error(err,
+			responder.WithComponentIfValidationErr(comp),
+			responder.WithComponentIfErrIs(errs.ErrIncompatibleUnits, comp))
 	}
 
-	return responder.OK(responder.WithOOBComponent(w, r.Context(), views.OrderOOB(ord)),
-		responder.WithComponent(views.CreateOrderForm(new(order.Order), prods, clients,
+	return responder.OK(responder.WithOOBComponent(w, r.Context(), views.OrderOOB(created)),
+		responder.WithComponent(views.CreateOrderForm(new(order.Order), prods, clients, materials,
 			views.NewDefaultOrderFormData())))
 }
 
@@ -76,15 +92,15 @@ func (h *handler) GetEditOrder(w http.ResponseWriter, r *http.Request) (templ.Co
 		return responder.Error(err)
 	}
 
-	prods, clients, err := h.getProdsAndClients(claims)
+	prods, clients, materials, err := h.getOrderFormOptions(claims)
 	if err != nil {
 		return responder.Error(err)
 	}
 
 	fd := new(views.OrderFormData)
-	h.fm.MapToForm(ord, nil, fd)
+	h.mapOrderToForm(ord, nil, fd)
 
-	return responder.OK(responder.WithComponent(views.EditOrder(ord, prods, clients, fd)))
+	return responder.OK(responder.WithComponent(views.EditOrder(ord, prods, clients, materials, fd)))
 }
 
 func (h *handler) EditOrder(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
@@ -97,30 +113,266 @@ func (h *handler) EditOrder(w http.ResponseWriter, r *http.Request) (templ.Compo
 		return responder.BadRequest()
 	}
 
-	ord, err = h.app.OrderService.UpdateOrderByID(claims, id, ord)
+	customItems, err := parseCustomItems(r)
 	if err != nil {
-		prods, clients, err := h.getProdsAndClients(claims)
+		return responder.Error(err)
+	}
+	ord.Items = append(ord.Items, customItems...)
+
+	refreshPrices := r.FormValue("refresh_prices") == "on"
+
+	updated, err := h.app.OrderService.UpdateOrderByID(claims, id, ord, refreshPrices,
+		order.WithPopulatedClient, order.WithPopulatedItemProducts)
+	if err != nil {
+		prods, clients, materials, getErr := h.getOrderFormOptions(claims)
+		if getErr != nil {
+			return responder.Error(getErr)
+		}
+		ord.ID = id
+		fd := new(views.OrderFormData)
+		h.mapOrderToForm(ord, err, fd)
+		mapCustomItemUnitError(ord, err, fd)
+		fd.RefreshPrices.Value = r.FormValue("refresh_prices")
+		comp := views.EditOrder(ord, prods, clients, materials, fd)
+		return responder.Error(err,
+			responder.WithComponentIfValidationErr(comp),
+			responder.WithComponentIfErrIs(errs.ErrIncompatibleUnits, comp))
+	}
+
+	return responder.OK(responder.WithComponent(views.Order(updated)))
+}
+
+func (h *handler) TransitionOrderStatus(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	to := order.StatusEnum(r.FormValue("status"))
+
+	// Warn before confirming an order there isn't enough material for, unless
+	// it's confirmed anyway.
+	if to == order.StatusConfirmed && r.FormValue("force") != "true" {
+		ord, err := h.app.OrderService.GetOrderByID(claims, id)
 		if err != nil {
 			return responder.Error(err)
 		}
-		fd := new(views.OrderFormData)
-		h.fm.MapToForm(ord, err, fd)
-		comp := views.EditOrder(ord, prods, clients, fd)
-		return responder.Error(err, responder.WithComponentIfValidationErr(comp))
+
+		shortages, err := h.app.StockService.GetOrderShortages(claims, ord)
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		if len(shortages) > 0 {
+			return responder.OK(responder.WithComponent(views.OrderShortages(ord, shortages)))
+		}
+	}
+
+	// Starting the work waits for the deposit, unless an admin starts it
+	// anyway.
+	if to == order.StatusInProgress {
+		if r.FormValue("force") == "true" {
+			ord, err := h.app.OrderService.StartOrderWaivingDeposit(claims, id)
+			if err != nil {
+				return responder.Error(err)
+			}
+			return responder.OK(responder.WithComponent(views.Order(ord)))
+		}
+
+		ord, err := h.app.OrderService.GetOrderByID(claims, id)
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		if claims.Role.IsAdmin() && ord.PaymentPlan != nil && !ord.DepositReceived() && ord.PaymentPlan.DepositWaivedBy == "" {
+			return responder.OK(responder.WithComponent(views.OrderDepositMissing(ord)))
+		}
+	}
+
+	ord, err := h.app.OrderService.TransitionOrderStatus(claims, id, to)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.Order(ord)))
+}
+
+func (h *handler) PromoteCustomItem(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	ord, err := h.app.OrderService.PromoteCustomItem(claims, r.PathValue("id"), r.PathValue("itemId"))
+	if err != nil {
+		return responder.Error(err)
 	}
 
 	return responder.OK(responder.WithComponent(views.Order(ord)))
 }
 
-func (h *handler) getProdsAndClients(claims *jwtadapter.AccessClaims) ([]product.Product, []client.Client, error) {
+func (h *handler) getOrderFormOptions(claims *jwtadapter.AccessClaims) ([]product.Product, []client.Client, []material.Material, error) {
 	prods, err := h.app.ProductService.GetProducts(claims)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	clients, err := h.app.ClientService.GetClients(claims)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
+	}
+	materials, err := h.app.MaterialService.GetMaterials(claims)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
-	return prods, clients, nil
+	return prods, clients, materials, nil
+}
+
+// mapOrderToForm maps the catalog items with the rest of the order, the
+// custom items have their own form fields.
+func (h *handler) mapOrderToForm(ord *order.Order, err error, fd *views.OrderFormData) {
+	catalogOrd := *ord
+	catalogOrd.Items, fd.CustomItems = splitCustomItems(ord.Items)
+	h.fm.MapToForm(&catalogOrd, err, fd)
+}
+
+// splitCustomItems separates the custom items from the catalog ones, and fills
+// their form data.
+// mapCustomItemUnitError shows the unit a custom item's material can't be
+// converted from on its material's row.
+func mapCustomItemUnitError(ord *order.Order, err error, fd *views.OrderFormData) {
+	var itemErr *order.ItemError
+	var usageErr *product.UsageError
+	var convErr *material.UnitConversionError
+	if !errors.As(err, &itemErr) || !errors.As(err, &usageErr) || !errors.As(err, &convErr) {
+		return
+	}
+	if itemErr.Index >= len(ord.Items) || !ord.Items[itemErr.Index].IsCustom() {
+		return
+	}
+
+	// The custom items are in the form in the order they're in the order.
+	idx := 0
+	for _, item := range ord.Items[:itemErr.Index] {
+		if item.IsCustom() {
+			idx++
+		}
+	}
+	if idx >= len(fd.CustomItems) || usageErr.Index >= len(fd.CustomItems[idx].Materials) {
+		return
+	}
+
+	fd.CustomItems[idx].Materials[usageErr.Index].Unit.Error = fmt.Sprintf("The material is in %s, %s can't be converted to it", convErr.To, convErr.From)
+}
+
+func splitCustomItems(items []order.Item) ([]order.Item, []views.CustomItemFormData) {
+	catalog := []order.Item{}
+	custom := []views.CustomItemFormData{}
+	for _, item := range items {
+		if !item.IsCustom() {
+			catalog = append(catalog, item)
+			continue
+		}
+
+		fd := views.CustomItemFormData{}
+		fd.ID.Value = item.ID
+		fd.Name.Value = item.Custom.Name
+		fd.Description.Value = item.Custom.Description
+		fd.Category.Value = string(item.Custom.Category)
+		if item.Custom.TimeToCraft != 0 {
+			fd.Hours.Value = strconv.FormatFloat(item.Custom.TimeToCraft.Hours(), 'f', -1, 64)
+		}
+		if item.Custom.Price != 0 {
+			fd.Price.Value = strconv.FormatFloat(item.Custom.Price, 'f', -1, 64)
+		}
+		fd.Quantity.Value = strconv.Itoa(int(item.Quantity))
+		fd.Materials = make([]views.CustomMaterialFormData, len(item.Custom.MaterialUsage))
+		for i, usage := range item.Custom.MaterialUsage {
+			fd.Materials[i].Material.Value = usage.MaterialID
+			fd.Materials[i].Quantity.Value = strconv.FormatFloat(usage.Quantity, 'f', -1, 64)
+			fd.Materials[i].Unit.Value = string(usage.Unit)
+		}
+		custom = append(custom, fd)
+	}
+	return catalog, custom
+}
+
+// parseCustomItems reads the custom items, they're sent as repeated id, name,
+// category, description, hours, price, and quantity fields in the same order.
+// The materials of each are sent as repeated fields keyed by its index.
+func parseCustomItems(r *http.Request) ([]order.Item, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	ids := r.Form["custom_item_id"]
+	names, categories, descriptions := r.Form["custom_name"], r.Form["custom_category"], r.Form["custom_description"]
+	hours, prices, quantities := r.Form["custom_hours"], r.Form["custom_price"], r.Form["custom_quantity"]
+	for _, field := range [][]string{names, categories, descriptions, hours, prices, quantities} {
+		if len(field) != len(ids) {
+			return nil, errs.ErrInvalidNumber
+		}
+	}
+
+	items := make([]order.Item, len(ids))
+	for i, id := range ids {
+		h, err := parseOptionalFloat(hours[i])
+		if err != nil {
+			return nil, err
+		}
+
+		price, err := parseOptionalFloat(prices[i])
+		if err != nil {
+			return nil, err
+		}
+
+		quantity, err := strconv.ParseUint(quantities[i], 10, 16)
+		if err != nil {
+			return nil, errs.ErrInvalidNumber
+		}
+
+		usage, err := parseCustomItemMaterials(r, i)
+		if err != nil {
+			return nil, err
+		}
+
+		items[i] = order.Item{
+			ID:       id,
+			Quantity: uint16(quantity),
+			Custom: &order.CustomItem{
+				Name:          names[i],
+				Description:   descriptions[i],
+				Category:      product.CategoryEnum(categories[i]),
+				TimeToCraft:   time.Duration(h * float64(time.Hour)),
+				MaterialUsage: usage,
+				Price:         price,
+			},
+		}
+	}
+	return items, nil
+}
+
+// parseCustomItemMaterials reads the materials of the custom item at the
+// index, the rows without a material are skipped.
+func parseCustomItemMaterials(r *http.Request, idx int) ([]product.MaterialUsage, error) {
+	ids := r.Form[fmt.Sprintf("custom_material-%d", idx)]
+	quantities := r.Form[fmt.Sprintf("custom_material_quantity-%d", idx)]
+	units := r.Form[fmt.Sprintf("custom_material_unit-%d", idx)]
+	if len(quantities) != len(ids) || len(units) != len(ids) {
+		return nil, errs.ErrInvalidNumber
+	}
+
+	usage := []product.MaterialUsage{}
+	for i, id := range ids {
+		if id == "" {
+			continue
+		}
+
+		quantity, err := parseOptionalFloat(quantities[i])
+		if err != nil {
+			return nil, err
+		}
+
+		usage = append(usage, product.MaterialUsage{
+			MaterialID: id,
+			Quantity:   quantity,
+			Unit:       material.Unit(strings.TrimSpace(units[i])),
+		})
+	}
+	return usage, nil
 }
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/omareloui/former"
+	"github.com/omareloui/odinls/internal/api/responder"
+	"github.com/omareloui/odinls/internal/application/core/order"
+	"github.com/omareloui/odinls/internal/errs"
+	"github.com/omareloui/odinls/web/views"
+)
+
+func (h *handler) GetOrderPayments(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	ord, err := h.app.OrderService.GetOrderByID(claims, id)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	comp := views.OrderPaymentsPage(claims, ord, views.NewPaymentFormData(), views.NewPaymentFormData())
+	return responder.OK(responder.WithComponent(comp))
+}
+
+func (h *handler) RecordOrderPayment(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	return h.addOrderPayment(w, r, false)
+}
+
+func (h *handler) RecordOrderRefund(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	return h.addOrderPayment(w, r, true)
+}
+
+func (h *handler) addOrderPayment(w http.ResponseWriter, r *http.Request, isRefund bool) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	payment := new(order.Payment)
+	if err := former.Populate(r, payment); err != nil {
+		return responder.BadRequest()
+	}
+
+	var (
+		ord *order.Order
+		err error
+	)
+	if isRefund {
+		ord, err = h.app.OrderService.RecordRefund(claims, id, payment)
+	} else {
+		ord, err = h.app.OrderService.RecordPayment(claims, id, payment)
+	}
+	if err != nil {
+		current, getErr := h.app.OrderService.GetOrderByID(claims, id)
+		if getErr != nil {
+			return responder.Error(getErr)
+		}
+
+		fd := new(views.PaymentFormData)
+		h.fm.MapToForm(payment, err, fd)
+		switch {
+		case errors.Is(err, order.ErrRefundWithoutReason):
+			fd.Reason.Error = "Write why the refund was made."
+		case errors.Is(err, order.ErrRefundOverPaid):
+			fd.Amount.Error = "The refund is more than what the client paid."
+		case errors.Is(err, errs.ErrInvalidEnum):
+			fd.Method.Error = "Select a valid method."
+		}
+
+		paymentFormData, refundFormData := fd, views.NewPaymentFormData()
+		if isRefund {
+			paymentFormData, refundFormData = refundFormData, paymentFormData
+		}
+		comp := views.OrderPayments(current, paymentFormData, refundFormData)
+		return responder.Error(err,
+			responder.WithComponentIfValidationErr(comp),
+			responder.WithComponentIfErrIs(errs.ErrInvalidPayment, comp),
+			responder.WithComponentIfErrIs(errs.ErrInvalidEnum, comp))
+	}
+
+	return responder.OK(responder.WithComponent(
+		views.OrderPayments(ord, views.NewPaymentFormData(), views.NewPaymentFormData())))
+}
+
+func (h *handler) GetPaymentReceipt(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	rec, err := h.app.InvoiceService.GetPaymentReceipt(claims, r.PathValue("id"), r.PathValue("paymentId"))
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.ReceiptPage(rec)))
+}
+
+func (h *handler) SetOrderPaymentPlan(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	plan, err := parsePaymentPlan(r)
+	var ord *order.Order
+	if err == nil {
+		ord, err = h.app.OrderService.SetPaymentPlan(claims, id, plan)
+	}
+	if err != nil {
+		current, getErr := h.app.OrderService.GetOrderByID(claims, id)
+		if getErr != nil {
+			return responder.Error(getErr)
+		}
+
+		fd := views.NewPaymentPlanFormData(plan)
+		fd.Error = "The deposit and every instalment need an amount above zero, every instalment needs a due date, and the plan can't be more than the order's total."
+		comp := views.OrderPaymentPlan(current, fd, false)
+		return responder.Error(err, responder.WithComponentIfValidationErr(comp),
+			responder.WithComponentIfErrIs(errs.ErrInvalidPayment, comp),
+			responder.WithComponentIfErrIs(errs.ErrInvalidFloat, comp),
+			responder.WithComponentIfErrIs(errs.ErrInvalidDate, comp))
+	}
+
+	return responder.OK(responder.WithComponent(
+		views.OrderPaymentPlan(ord, views.NewPaymentPlanFormData(ord.PaymentPlan), true)))
+}
+
+func (h *handler) GetReceivables(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	receivables, err := h.app.OrderService.GetReceivables(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.ReceivablesPage(claims, receivables)))
+}
+
+// parsePaymentPlan reads the order's payment plan, the instalments are sent as
+// repeated fields and the rows without an amount are skipped.
+func parsePaymentPlan(r *http.Request) (*order.PaymentPlan, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	deposit, err := parseOptionalFloat(r.FormValue("deposit"))
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &order.PaymentPlan{
+		Deposit:             deposit,
+		DepositIsPercentage: r.FormValue("deposit_is_percentage") == "on",
+		Instalments:         []order.Instalment{},
+	}
+
+	amounts, dueDates := r.Form["instalment_amount"], r.Form["instalment_due_date"]
+	if len(dueDates) != len(amounts) {
+		return plan, errs.ErrInvalidNumber
+	}
+
+	for i, amount := range amounts {
+		if amount == "" {
+			continue
+		}
+
+		parsed, err := parseOptionalFloat(amount)
+		if err != nil {
+			return plan, err
+		}
+
+		dueDate, err := time.Parse(time.DateOnly, dueDates[i])
+		if err != nil {
+			return plan, errs.ErrInvalidDate
+		}
+
+		plan.Instalments = append(plan.Instalments, order.Instalment{Amount: parsed, DueDate: dueDate})
+	}
+	return plan, nil
+}
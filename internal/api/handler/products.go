@@ -2,11 +2,16 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/a-h/templ"
 	"github.com/omareloui/former"
+	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
 	"github.com/omareloui/odinls/internal/api/responder"
+	"github.com/omareloui/odinls/internal/application/core/costing"
 	"github.com/omareloui/odinls/internal/application/core/product"
+	"github.com/omareloui/odinls/internal/application/core/user"
+	"github.com/omareloui/odinls/internal/errs"
 	"github.com/omareloui/odinls/internal/logger"
 	"github.com/omareloui/odinls/web/views"
 	"go.uber.org/zap"
@@ -25,8 +30,13 @@ func (h *handler) GetProducts(w http.ResponseWriter, r *http.Request) (templ.Com
 		return responder.Error(err)
 	}
 
+	settings, craftsmen, err := h.getPricingData(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
 	l.Debug("rendering the products page...")
-	comp := views.ProductsPage(claims, prods)
+	comp := views.ProductsPage(claims, prods, settings, craftsmen)
 	return responder.OK(responder.WithComponent(comp))
 }
 
@@ -39,18 +49,23 @@ func (h *handler) CreateProduct(w http.ResponseWriter, r *http.Request) (templ.C
 		return responder.BadRequest()
 	}
 
+	settings, craftsmen, err := h.getPricingData(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
 	prod, err = h.app.ProductService.CreateProduct(claims, prod)
 	if err != nil {
 		fd := new(views.ProductFormData)
 		h.fm.MapToForm(prod, err, fd)
-		comp := views.CreateProductForm(prod, fd, claims.Craftsman.HourlyRate)
+		comp := views.CreateProductForm(prod, fd, settings, craftsmen)
 		return responder.Error(err, responder.WithComponentIfValidationErr(comp))
 	}
 
-	oobComp := views.ProductOOB(prod, claims.Craftsman.HourlyRate)
+	oobComp := views.ProductOOB(prod, settings)
 	comp := views.CreateProductForm(&product.Product{},
 		&views.ProductFormData{Variants: []views.ProductVariantFormData{{}}},
-		claims.Craftsman.HourlyRate)
+		settings, craftsmen)
 	return responder.OK(responder.WithOOBComponent(w, r.Context(), oobComp),
 		responder.WithComponent(comp))
 }
@@ -63,7 +78,13 @@ func (h *handler) GetProduct(w http.ResponseWriter, r *http.Request) (templ.Comp
 	if err != nil {
 		return responder.Error(err)
 	}
-	comp := views.Product(prod, claims.Craftsman.HourlyRate)
+
+	settings, err := h.app.CostingService.GetSettings(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	comp := views.Product(prod, settings)
 	return responder.OK(responder.WithComponent(comp))
 }
 
@@ -75,9 +96,15 @@ func (h *handler) GetEditProduct(w http.ResponseWriter, r *http.Request) (templ.
 	if err != nil {
 		return responder.Error(err)
 	}
+
+	settings, craftsmen, err := h.getPricingData(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
 	fd := new(views.ProductFormData)
 	h.fm.MapToForm(prod, nil, fd)
-	comp := views.EditProduct(prod, fd, claims.Craftsman.HourlyRate)
+	comp := views.EditProduct(prod, fd, settings, craftsmen)
 	return responder.OK(responder.WithComponent(comp))
 }
 
@@ -91,13 +118,184 @@ func (h *handler) EditProduct(w http.ResponseWriter, r *http.Request) (templ.Com
 		return responder.BadRequest()
 	}
 
+	settings, craftsmen, err := h.getPricingData(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
 	prod, err = h.app.ProductService.UpdateProductByID(claims, id, prod)
 	if err != nil {
 		fd := new(views.ProductFormData)
 		h.fm.MapToForm(prod, err, fd)
-		comp := views.EditProduct(prod, fd, claims.Craftsman.HourlyRate)
+		comp := views.EditProduct(prod, fd, settings, craftsmen)
 		return responder.Error(err, responder.WithComponentIfValidationErr(comp))
 	}
 
-	return responder.OK(responder.WithComponent(views.Product(prod, claims.Craftsman.HourlyRate)))
+	return responder.OK(responder.WithComponent(views.Product(prod, settings)))
+}
+
+func (h *handler) GetPriceReviews(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	reviews, err := h.app.ProductService.GetPriceReviews(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.PriceReviewsPage(claims, reviews)))
+}
+
+func (h *handler) GetCapacities(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	capacities, err := h.app.ProductService.GetCapacities(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.CapacityPage(claims, capacities)))
+}
+
+func (h *handler) GetShortfalls(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	if err := r.ParseForm(); err != nil {
+		return responder.BadRequest()
+	}
+
+	variantsIDs, quantities := r.Form["variant_id"], r.Form["quantity"]
+	if len(variantsIDs) != len(quantities) {
+		return responder.BadRequest()
+	}
+
+	wishes := []product.Wish{}
+	for i, id := range variantsIDs {
+		if quantities[i] == "" {
+			continue
+		}
+		quantity, err := strconv.Atoi(quantities[i])
+		if err != nil {
+			return responder.Error(errs.ErrInvalidNumber)
+		}
+		wishes = append(wishes, product.Wish{VariantID: id, Quantity: quantity})
+	}
+
+	shortfalls, err := h.app.ProductService.GetShortfalls(claims, wishes)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.Shortfalls(shortfalls)))
+}
+
+func (h *handler) getPricingData(claims *jwtadapter.AccessClaims) (*costing.Settings, []user.User, error) {
+	settings, err := h.app.CostingService.GetSettings(claims)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	craftsmen, err := h.getCraftsmen()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return settings, craftsmen, nil
+}
+
+func (h *handler) GetVariantTierPrices(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	prod, variant, err := h.getProductVariant(claims, r.PathValue("id"), r.PathValue("variantId"))
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.VariantTierPricesPage(claims, prod, variant)))
+}
+
+func (h *handler) SetVariantTierPrices(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+	variantID := r.PathValue("variantId")
+
+	prod, variant, err := h.getProductVariant(claims, r.PathValue("id"), variantID)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	prices, err := parseTierPrices(r)
+	var updated *product.Product
+	if err == nil {
+		updated, err = h.app.ProductService.SetVariantTierPrices(claims, variantID, prices)
+	}
+	if err != nil {
+		comp := views.VariantTierPricesForm(prod, variant, views.NewTierPricesFormData(prices),
+			"Every price needs a tier, a minimum quantity of one or more, and a price above zero.")
+		return responder.Error(err, responder.WithComponentIfValidationErr(comp),
+			responder.WithComponentIfErrIs(errs.ErrInvalidNumber, comp),
+			responder.WithComponentIfErrIs(errs.ErrInvalidFloat, comp),
+			responder.WithComponentIfErrIs(errs.ErrInvalidEnum, comp))
+	}
+
+	_, variant, err = findVariant(updated, variantID)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(
+		views.VariantTierPricesForm(updated, variant, views.NewTierPricesFormData(variant.TierPrices), "")))
+}
+
+func (h *handler) getProductVariant(claims *jwtadapter.AccessClaims, productID, variantID string) (*product.Product, *product.Variant, error) {
+	prod, err := h.app.ProductService.GetProductByID(claims, productID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return findVariant(prod, variantID)
+}
+
+func findVariant(prod *product.Product, variantID string) (*product.Product, *product.Variant, error) {
+	for i := range prod.Variants {
+		if prod.Variants[i].ID == variantID {
+			return prod, &prod.Variants[i], nil
+		}
+	}
+	return nil, nil, errs.ErrDocumentNotFound
+}
+
+// parseTierPrices reads the variant's tier prices, they're sent as repeated
+// tier, minimum quantity, and price fields in the same order. The rows
+// without a price are skipped.
+func parseTierPrices(r *http.Request) ([]product.TierPrice, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	tiers, minQuantities, prices := r.Form["tier"], r.Form["min_quantity"], r.Form["price"]
+	if len(minQuantities) != len(tiers) || len(prices) != len(tiers) {
+		return nil, errs.ErrInvalidNumber
+	}
+
+	tierPrices := []product.TierPrice{}
+	for i, tier := range tiers {
+		if prices[i] == "" {
+			continue
+		}
+
+		price, err := parseOptionalFloat(prices[i])
+		if err != nil {
+			return nil, err
+		}
+
+		minQuantity, err := strconv.ParseUint(minQuantities[i], 10, 16)
+		if err != nil {
+			return nil, errs.ErrInvalidNumber
+		}
+
+		tierPrices = append(tierPrices, product.TierPrice{
+			Tier:        product.PriceTierEnum(tier),
+			MinQuantity: uint16(minQuantity),
+			Price:       price,
+		})
+	}
+	return tierPrices, nil
 }
@@ -0,0 +1,313 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/a-h/templ"
+	"github.com/omareloui/former"
+	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+	"github.com/omareloui/odinls/internal/api/responder"
+	"github.com/omareloui/odinls/internal/application/core/material"
+	"github.com/omareloui/odinls/internal/application/core/purchase"
+	"github.com/omareloui/odinls/internal/application/core/supplier"
+	"github.com/omareloui/odinls/internal/errs"
+	"github.com/omareloui/odinls/internal/logger"
+	"github.com/omareloui/odinls/web/views"
+	"go.uber.org/zap"
+)
+
+func (h *handler) GetPurchaseOrders(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	pos, err := h.app.PurchaseService.GetPurchaseOrders(claims, purchase.WithPopulatedSupplier)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	suppliers, materials, err := h.getSuppliersAndMaterials(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	comp := views.PurchasesPage(claims, pos, suppliers, materials, views.NewDefaultPurchaseOrderFormData())
+	return responder.OK(responder.WithComponent(comp))
+}
+
+func (h *handler) CreatePurchaseOrder(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	po := new(purchase.PurchaseOrder)
+	if err := former.Populate(r, po); err != nil {
+		return responder.BadRequest()
+	}
+
+	lines, err := parsePurchaseLines(r)
+	if err != nil {
+		return responder.Error(err)
+	}
+	po.Lines = lines
+
+	suppliers, materials, err := h.getSuppliersAndMaterials(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	created, err := h.app.PurchaseService.CreatePurchaseOrder(claims, po, purchase.WithPopulatedSupplier)
+	if err != nil {
+		fd := new(views.PurchaseOrderFormData)
+		h.fm.MapToForm(po, err, fd)
+		fd.Lines = purchaseLinesFormData(lines)
+
+		var lineErr *purchase.LineError
+		var convErr *material.UnitConversionError
+		if errors.As(err, &lineErr) && errors.As(err, &convErr) {
+			fd.Lines[lineErr.Index].Unit.Error = fmt.Sprintf("The material is in %s, %s can't be converted to it", convErr.To, convErr.From)
+		}
+		if errors.As(err, &lineErr) && errors.Is(err, purchase.ErrDuplicateLine) {
+			fd.Lines[lineErr.Index].Material.Error = "The material is already in another line"
+		}
+
+		comp := views.CreatePurchaseOrderForm(fd, suppliers, materials)
+		return responder.Error(err,
+			responder.WithComponentIfValidationErr(comp),
+			responder.WithComponentIfErrIs(errs.ErrIncompatibleUnits, comp),
+			responder.WithComponentIfErrIs(purchase.ErrDuplicateLine, comp))
+	}
+
+	return responder.Created(responder.WithOOBComponent(w, r.Context(), views.PurchaseOrderOOB(created)),
+		responder.WithComponent(views.CreatePurchaseOrderForm(views.NewDefaultPurchaseOrderFormData(), suppliers, materials)))
+}
+
+func (h *handler) GetPurchaseOrder(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	po, err := h.app.PurchaseService.GetPurchaseOrderByID(claims, id, purchase.WithPopulatedSupplier)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.PurchaseOrder(po)))
+}
+
+func (h *handler) TransitionPurchaseOrderStatus(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	to := purchase.StatusEnum(r.FormValue("status"))
+
+	po, err := h.app.PurchaseService.TransitionPurchaseOrderStatus(claims, id, to, purchase.WithPopulatedSupplier)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.PurchaseOrder(po)))
+}
+
+func (h *handler) GetReceiveGoods(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	po, err := h.app.PurchaseService.GetPurchaseOrderByID(claims, id)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.ReceiveGoodsForm(po, "")))
+}
+
+func (h *handler) ReceiveGoods(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	receipts, err := parseReceipts(r)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	po, err := h.app.PurchaseService.ReceiveGoods(claims, id, receipts, purchase.WithPopulatedSupplier)
+	if err != nil && po != nil {
+		// The goods got into the stock, only recording their lots failed.
+		logger.FromCtx(r.Context()).Error("recording the received lots", zap.Error(err), zap.String("purchase_order_id", id))
+		err = nil
+	}
+	if err != nil {
+		current, getErr := h.app.PurchaseService.GetPurchaseOrderByID(claims, id)
+		if getErr != nil {
+			return responder.Error(getErr)
+		}
+		comp := views.ReceiveGoodsForm(current, "The received quantities can't be more than what's remaining, nor the rejected ones negative.")
+		return responder.Error(err, responder.WithComponentIfErrIs(errs.ErrInvalidNumber, comp))
+	}
+
+	return responder.OK(responder.WithComponent(views.PurchaseOrder(po)))
+}
+
+func (h *handler) GetSupplierPurchaseOrders(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	sup, err := h.app.SupplierService.GetSupplierByID(claims, id)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	pos, err := h.app.PurchaseService.GetSupplierPurchaseOrders(claims, id, purchase.WithPopulatedSupplier)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.SupplierPurchasesPage(claims, sup, pos)))
+}
+
+func (h *handler) GetSupplierScorecard(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	sc, err := h.app.PurchaseService.GetSupplierScorecard(claims, id, purchase.PeriodEnum(r.URL.Query().Get("period")))
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.SupplierScorecardPage(claims, sc)))
+}
+
+func (h *handler) GetSupplierScorecardCSV(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	sc, err := h.app.PurchaseService.GetSupplierScorecard(claims, id, purchase.PeriodEnum(r.URL.Query().Get("period")))
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="scorecard-%s.csv"`, sc.Supplier.ID))
+
+	comp := templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		return sc.WriteCSV(w)
+	})
+	return responder.OK(responder.WithComponent(comp))
+}
+
+func (h *handler) getSuppliersAndMaterials(claims *jwtadapter.AccessClaims) ([]supplier.Supplier, []material.Material, error) {
+	suppliers, err := h.app.SupplierService.GetSuppliers(claims)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	materials, err := h.app.MaterialService.GetMaterials(claims)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return suppliers, materials, nil
+}
+
+// parsePurchaseLines reads the purchase order's lines, they're sent as
+// repeated material, quantity, unit, and unit price fields in the same order.
+func parsePurchaseLines(r *http.Request) ([]purchase.Line, error) {
+	ids, quantities, prices, err := parsePricedQuantities(r, "line_material", "line_quantity", "line_unit_price")
+	if err != nil {
+		return nil, err
+	}
+
+	units := r.Form["line_unit"]
+	if len(units) != 0 && len(units) != len(ids) {
+		return nil, errs.ErrInvalidNumber
+	}
+
+	lines := make([]purchase.Line, len(ids))
+	for i, id := range ids {
+		lines[i] = purchase.Line{MaterialID: id, Quantity: quantities[i], UnitPrice: prices[i]}
+		if len(units) != 0 {
+			lines[i].Unit = material.Unit(strings.TrimSpace(units[i]))
+		}
+	}
+	return lines, nil
+}
+
+// purchaseLinesFormData fills the lines' form data with what was sent, to
+// show the form again with the errors.
+func purchaseLinesFormData(lines []purchase.Line) []views.PurchaseLineFormData {
+	if len(lines) == 0 {
+		return []views.PurchaseLineFormData{{}}
+	}
+
+	fd := make([]views.PurchaseLineFormData, len(lines))
+	for i, line := range lines {
+		fd[i].Material.Value = line.MaterialID
+		fd[i].Quantity.Value = strconv.FormatFloat(line.Quantity, 'f', -1, 64)
+		fd[i].Unit.Value = string(line.Unit)
+		if line.UnitPrice != 0 {
+			fd[i].UnitPrice.Value = strconv.FormatFloat(line.UnitPrice, 'f', -1, 64)
+		}
+	}
+	return fd
+}
+
+// parseReceipts reads the received goods, they're sent the same way as the
+// purchase order's lines.
+func parseReceipts(r *http.Request) ([]purchase.Receipt, error) {
+	ids, quantities, prices, err := parsePricedQuantities(r, "material_id", "quantity", "unit_price")
+	if err != nil {
+		return nil, err
+	}
+
+	rejected := r.Form["rejected"]
+	if len(rejected) != 0 && len(rejected) != len(ids) {
+		return nil, errs.ErrInvalidNumber
+	}
+
+	receipts := make([]purchase.Receipt, len(ids))
+	for i, id := range ids {
+		receipts[i] = purchase.Receipt{MaterialID: id, Quantity: quantities[i], UnitPrice: prices[i]}
+		if len(rejected) != 0 {
+			if receipts[i].Rejected, err = parseOptionalFloat(rejected[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return receipts, nil
+}
+
+func parsePricedQuantities(r *http.Request, idKey, quantityKey, priceKey string) ([]string, []float64, []float64, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	ids, rawQuantities, rawPrices := r.Form[idKey], r.Form[quantityKey], r.Form[priceKey]
+	if len(rawQuantities) != len(ids) || len(rawPrices) != len(ids) {
+		return nil, nil, nil, errs.ErrInvalidNumber
+	}
+
+	quantities, prices := make([]float64, len(ids)), make([]float64, len(ids))
+	for i := range ids {
+		var err error
+		if quantities[i], err = parseOptionalFloat(rawQuantities[i]); err != nil {
+			return nil, nil, nil, err
+		}
+		if prices[i], err = parseOptionalFloat(rawPrices[i]); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	return ids, quantities, prices, nil
+}
+
+func parseOptionalFloat(s string) (float64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, errs.ErrInvalidFloat
+	}
+	return f, nil
+}
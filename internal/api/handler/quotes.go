@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/a-h/templ"
+	"github.com/omareloui/former"
+	"github.com/omareloui/odinls/internal/api/responder"
+	"github.com/omareloui/odinls/internal/application/core/quote"
+	"github.com/omareloui/odinls/web/views"
+)
+
+func (h *handler) GetQuotes(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	quotes, err := h.app.QuoteService.GetQuotes(claims, quote.WithPopulatedClient)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	prods, clients, materials, err := h.getOrderFormOptions(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.QuotesPage(claims, prods, clients, materials, quotes)))
+}
+
+func (h *handler) CreateQuote(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	q := new(quote.Quote)
+	if err := former.Populate(r, q); err != nil {
+		return responder.BadRequest()
+	}
+
+	customItems, err := parseCustomItems(r)
+	if err != nil {
+		return responder.Error(err)
+	}
+	q.Items = append(q.Items, customItems...)
+
+	prods, clients, materials, err := h.getOrderFormOptions(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	created, err := h.app.QuoteService.CreateQuote(claims, q, quote.WithPopulatedClient)
+	if err != nil {
+		fd := new(views.QuoteFormData)
+		h.mapQuoteToForm(q, err, fd)
+		comp := views.CreateQuoteForm(q, prods, clients, materials, fd)
+		return responder.Error(err, responder.WithComponentIfValidationErr(comp))
+	}
+
+	return responder.OK(responder.WithOOBComponent(w, r.Context(), views.QuoteOOB(created)),
+		responder.WithComponent(views.CreateQuoteForm(new(quote.Quote), prods, clients, materials,
+			views.NewDefaultQuoteFormData())))
+}
+
+func (h *handler) GetQuote(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	q, err := h.app.QuoteService.GetQuoteByID(claims, r.PathValue("id"), quote.WithPopulatedClient)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.Quote(q)))
+}
+
+func (h *handler) GetEditQuote(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	q, err := h.app.QuoteService.GetQuoteByID(claims, r.PathValue("id"))
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	prods, clients, materials, err := h.getOrderFormOptions(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	fd := new(views.QuoteFormData)
+	h.mapQuoteToForm(q, nil, fd)
+
+	return responder.OK(responder.WithComponent(views.EditQuote(q, prods, clients, materials, fd)))
+}
+
+func (h *handler) EditQuote(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	q := new(quote.Quote)
+	if err := former.Populate(r, q); err != nil {
+		return responder.BadRequest()
+	}
+
+	customItems, err := parseCustomItems(r)
+	if err != nil {
+		return responder.Error(err)
+	}
+	q.Items = append(q.Items, customItems...)
+
+	updated, err := h.app.QuoteService.UpdateQuoteByID(claims, id, q, quote.WithPopulatedClient)
+	if err != nil {
+		prods, clients, materials, getErr := h.getOrderFormOptions(claims)
+		if getErr != nil {
+			return responder.Error(getErr)
+		}
+		q.ID = id
+		fd := new(views.QuoteFormData)
+		h.mapQuoteToForm(q, err, fd)
+		comp := views.EditQuote(q, prods, clients, materials, fd)
+		return responder.Error(err, responder.WithComponentIfValidationErr(comp))
+	}
+
+	return responder.OK(responder.WithComponent(views.Quote(updated)))
+}
+
+func (h *handler) TransitionQuoteStatus(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	to := quote.StatusEnum(r.FormValue("status"))
+
+	q, err := h.app.QuoteService.TransitionQuoteStatus(claims, r.PathValue("id"), to, quote.WithPopulatedClient)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.Quote(q)))
+}
+
+func (h *handler) ConvertQuote(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	if _, err := h.app.QuoteService.ConvertQuote(claims, id); err != nil {
+		return responder.Error(err)
+	}
+
+	q, err := h.app.QuoteService.GetQuoteByID(claims, id, quote.WithPopulatedClient)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.Quote(q)))
+}
+
+func (h *handler) GetQuoteDocument(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	q, err := h.app.QuoteService.GetQuoteByID(claims, r.PathValue("id"), quote.WithPopulatedClient)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.QuoteDocumentPage(q)))
+}
+
+// mapQuoteToForm maps the quote the same way the orders are mapped.
+func (h *handler) mapQuoteToForm(q *quote.Quote, err error, fd *views.QuoteFormData) {
+	catalogQuote := *q
+	catalogQuote.Items, fd.CustomItems = splitCustomItems(q.Items)
+	h.fm.MapToForm(&catalogQuote, err, fd)
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/a-h/templ"
+	"github.com/omareloui/former"
+	"github.com/omareloui/odinls/internal/api/responder"
+	"github.com/omareloui/odinls/internal/application/core/stock"
+	"github.com/omareloui/odinls/internal/errs"
+	"github.com/omareloui/odinls/web/views"
+)
+
+func (h *handler) GetMaterialMovements(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	mat, err := h.app.MaterialService.GetMaterialByID(claims, id)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	movements, err := h.app.StockService.GetMaterialMovements(claims, id)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(
+		views.MaterialMovementsPage(claims, mat, movements, new(views.MovementFormData))))
+}
+
+func (h *handler) CreateMaterialMovement(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	mv := new(stock.Movement)
+	err := former.Populate(r, mv)
+	if err != nil {
+		return responder.BadRequest()
+	}
+	mv.MaterialID = id
+
+	_, mvErr := h.app.StockService.RecordMovement(claims, mv)
+
+	mat, err := h.app.MaterialService.GetMaterialByID(claims, id)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	movements, err := h.app.StockService.GetMaterialMovements(claims, id)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	if mvErr != nil {
+		fd := new(views.MovementFormData)
+		h.fm.MapToForm(mv, mvErr, fd)
+		if errors.Is(mvErr, errs.ErrInsufficientStock) {
+			fd.Quantity.Error = "There isn't enough stock for this"
+		}
+		comp := views.StockLedger(mat, movements, fd)
+		return responder.Error(mvErr,
+			responder.WithComponentIfValidationErr(comp),
+			responder.WithComponentIfErrIs(errs.ErrInsufficientStock, comp))
+	}
+
+	return responder.Created(responder.WithComponent(
+		views.StockLedger(mat, movements, new(views.MovementFormData))))
+}
@@ -2,11 +2,13 @@ package handler
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/a-h/templ"
 	"github.com/omareloui/former"
 	"github.com/omareloui/odinls/internal/api/responder"
 	"github.com/omareloui/odinls/internal/application/core/supplier"
+	"github.com/omareloui/odinls/internal/errs"
 	"github.com/omareloui/odinls/internal/logger"
 	"github.com/omareloui/odinls/web/views"
 	"go.uber.org/zap"
@@ -35,15 +37,20 @@ func (h *handler) CreateSupplier(w http.ResponseWriter, r *http.Request) (templ.
 	if err != nil {
 		return responder.BadRequest()
 	}
+	sup.Contacts, err = parseContacts(r)
+	if err != nil {
+		return responder.BadRequest()
+	}
 
-	sup, err = h.app.SupplierService.CreateSupplier(claims, sup)
+	created, err := h.app.SupplierService.CreateSupplier(claims, sup)
 	if err != nil {
-		var fd *views.SupplierFormData
+		fd := new(views.SupplierFormData)
 		h.fm.MapToForm(sup, err, fd)
+		fd.Contacts = views.NewSupplierContactsFormData(sup.Contacts)
 		return responder.Error(err, responder.WithComponentIfValidationErr(views.CreateSupplierForm(fd)))
 	}
 
-	return responder.Created(responder.WithOOBComponent(w, r.Context(), views.SupplierOOB(sup)),
+	return responder.Created(responder.WithOOBComponent(w, r.Context(), views.SupplierOOB(created)),
 		responder.WithComponent(views.CreateSupplierForm(new(views.SupplierFormData))))
 }
 
@@ -68,6 +75,7 @@ func (h *handler) GetEditSupplier(w http.ResponseWriter, r *http.Request) (templ
 
 	fd := new(views.SupplierFormData)
 	h.fm.MapToForm(sup, nil, fd)
+	fd.Contacts = views.NewSupplierContactsFormData(sup.Contacts)
 	return responder.OK(responder.WithComponent(views.EditSupplier(sup, fd)))
 }
 
@@ -80,14 +88,138 @@ func (h *handler) EditSupplier(w http.ResponseWriter, r *http.Request) (templ.Co
 	if err != nil {
 		return responder.BadRequest()
 	}
+	sup.ID = id
+	sup.Contacts, err = parseContacts(r)
+	if err != nil {
+		return responder.BadRequest()
+	}
 
-	sup, err = h.app.SupplierService.UpdateSupplierByID(claims, id, sup)
+	updated, err := h.app.SupplierService.UpdateSupplierByID(claims, id, sup)
 	if err != nil {
 		fd := new(views.SupplierFormData)
 		h.fm.MapToForm(sup, err, fd)
+		fd.Contacts = views.NewSupplierContactsFormData(sup.Contacts)
 		return responder.Error(err,
 			responder.WithComponentIfValidationErr(views.EditSupplier(sup, fd)))
 	}
 
-	return responder.OK(responder.WithComponent(views.Supplier(sup)))
+	return responder.OK(responder.WithComponent(views.Supplier(updated)))
+}
+
+func (h *handler) GetSupplierPrices(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	sup, err := h.app.SupplierService.GetSupplierByID(claims, id)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	materials, err := h.app.MaterialService.GetMaterials(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(
+		views.SupplierPricesPage(claims, sup, materials, new(views.PriceListEntryFormData))))
+}
+
+func (h *handler) SetSupplierPrice(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	entry := new(supplier.PriceListEntry)
+	err := former.Populate(r, entry)
+	if err != nil {
+		return responder.BadRequest()
+	}
+	entry.MaterialID = r.FormValue("material_id")
+
+	materials, err := h.app.MaterialService.GetMaterials(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	sup, err := h.app.SupplierService.SetPriceListEntry(claims, id, entry)
+	if err != nil {
+		current, getErr := h.app.SupplierService.GetSupplierByID(claims, id)
+		if getErr != nil {
+			return responder.Error(getErr)
+		}
+		fd := new(views.PriceListEntryFormData)
+		h.fm.MapToForm(entry, err, fd)
+		fd.Material.Value = entry.MaterialID
+		if entry.MaterialID == "" {
+			fd.Material.Error = "Select a material."
+		}
+		return responder.Error(err,
+			responder.WithComponentIfValidationErr(views.SupplierPriceList(current, materials, fd)))
+	}
+
+	return responder.OK(responder.WithComponent(
+		views.SupplierPriceList(sup, materials, new(views.PriceListEntryFormData))))
+}
+
+func (h *handler) RemoveSupplierPrice(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	sup, err := h.app.SupplierService.RemovePriceListEntry(claims, id, r.PathValue("materialId"))
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	materials, err := h.app.MaterialService.GetMaterials(claims)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(
+		views.SupplierPriceList(sup, materials, new(views.PriceListEntryFormData))))
+}
+
+func (h *handler) GetMaterialSuppliers(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	id := r.PathValue("id")
+	claims := getClaims(r.Context())
+
+	mat, err := h.app.MaterialService.GetMaterialByID(claims, id)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	offers, err := h.app.SupplierService.GetMaterialOffers(claims, id)
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.MaterialSuppliersPage(claims, mat, offers)))
+}
+
+// parseContacts reads the supplier's contacts, they're sent as repeated name,
+// phone, email, and whatsapp fields in the same order. The rows without a name
+// are skipped.
+func parseContacts(r *http.Request) ([]supplier.Contact, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	names := r.Form["contact_name"]
+	phones, emails, whatsapps := r.Form["contact_phone"], r.Form["contact_email"], r.Form["contact_whatsapp"]
+	if len(phones) != len(names) || len(emails) != len(names) || len(whatsapps) != len(names) {
+		return nil, errs.ErrInvalidNumber
+	}
+
+	contacts := []supplier.Contact{}
+	for i, name := range names {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		contacts = append(contacts, supplier.Contact{
+			Name:     name,
+			Phone:    phones[i],
+			Email:    emails[i],
+			WhatsApp: whatsapps[i],
+		})
+	}
+	return contacts, nil
 }
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/a-h/templ"
+	"github.com/omareloui/odinls/internal/api/responder"
+	"github.com/omareloui/odinls/internal/errs"
+	"github.com/omareloui/odinls/web/views"
+)
+
+func (h *handler) GetTrackOrder(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	claims := getClaims(r.Context())
+
+	if ref := r.URL.Query().Get("ref"); ref != "" {
+		return responder.Redirect(w, responder.WithPath(fmt.Sprintf("/track/%s", url.PathEscape(ref))))
+	}
+
+	return responder.OK(responder.WithComponent(views.TrackingPage(claims, "", nil)))
+}
+
+func (h *handler) TrackOrder(w http.ResponseWriter, r *http.Request) (templ.Component, error) {
+	ref := r.PathValue("ref")
+	claims := getClaims(r.Context())
+
+	tracking, err := h.app.OrderService.GetOrderTrackingByRef(ref)
+	if err != nil {
+		if errors.Is(err, errs.ErrDocumentNotFound) {
+			return responder.NotFound(responder.WithComponent(views.TrackingPage(claims, ref, nil)))
+		}
+		return responder.Error(err)
+	}
+
+	return responder.OK(responder.WithComponent(views.TrackingPage(claims, ref, tracking)))
+}
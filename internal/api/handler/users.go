@@ -132,3 +132,18 @@ func (h *handler) GetCraftsmanForm(w http.ResponseWriter, r *http.Request) (temp
 	comp := views.CraftsmanForm(&views.UserFormData{})
 	return responder.OK(responder.WithComponent(comp))
 }
+
+func (h *handler) getCraftsmen() ([]user.User, error) {
+	users, err := h.app.UserService.GetUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	craftsmen := []user.User{}
+	for _, usr := range users {
+		if usr.IsCraftsman() {
+			craftsmen = append(craftsmen, usr)
+		}
+	}
+	return craftsmen, nil
+}
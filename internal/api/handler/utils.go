@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"net/http"
 	"regexp"
 
 	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
@@ -25,3 +26,7 @@ func getRefreshClaims(ctx context.Context) *jwtadapter.RefreshClaims {
 	}
 	return v.(*jwtadapter.RefreshClaims)
 }
+
+func isHXRequest(r *http.Request) bool {
+	return r.Header.Get("HX-Request") == "true"
+}
@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/omareloui/odinls/internal/errs"
+	"github.com/omareloui/odinls/internal/logger"
+	"go.uber.org/zap"
+)
+
+const throttleSweepThreshold = 1024
+
+type failuresWindow struct {
+	count int
+	start time.Time
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// ThrottleFailures blocks the clients that got more than maxFailures responses
+// with any of the given statuses within the window. It's meant to slow down
+// guessing on public lookups, successful requests are never counted.
+func ThrottleFailures(maxFailures int, window time.Duration, statuses ...int) func(http.Handler) http.Handler {
+	var mu sync.Mutex
+	failures := map[string]*failuresWindow{}
+
+	isFailure := func(status int) bool {
+		for _, s := range statuses {
+			if s == status {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := clientIP(r)
+			now := time.Now()
+
+			mu.Lock()
+			f, ok := failures[key]
+			if ok && now.Sub(f.start) > window {
+				delete(failures, key)
+				ok = false
+			}
+			blocked := ok && f.count >= maxFailures
+			var retryAfter int
+			if blocked {
+				retryAfter = int(f.start.Add(window).Sub(now).Seconds()) + 1
+			}
+			mu.Unlock()
+
+			if blocked {
+				l := logger.FromCtx(r.Context())
+				l.Warn("throttled client", zap.String("ip", key), zap.String("path", r.URL.Path))
+
+				httperr := errs.NewRespError(http.StatusTooManyRequests, "Too many attempts, try again later")
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				http.Error(w, httperr.Message, httperr.Code)
+				return
+			}
+
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			if !isFailure(rec.status) {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if len(failures) > throttleSweepThreshold {
+				for k, v := range failures {
+					if now.Sub(v.start) > window {
+						delete(failures, k)
+					}
+				}
+			}
+
+			if f, ok := failures[key]; ok {
+				f.count++
+			} else {
+				failures[key] = &failuresWindow{count: 1, start: now}
+			}
+		})
+	}
+}
+
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
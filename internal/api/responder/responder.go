@@ -205,19 +205,54 @@ func Error(err error, opts ...responseOptsFunc) (templ.Component, error) {
 		errors.Is(err, errs.ErrInvalidID) ||
 		errors.Is(err, errs.ErrInvalidFloat) ||
 		errors.Is(err, errs.ErrInvalidNumber) ||
-		errors.Is(err, errs.ErrInvalidDate) {
+		errors.Is(err, errs.ErrInvalidDate) ||
+		errors.Is(err, errs.ErrInvalidEnum) ||
+		errors.Is(err, errs.ErrIncompatibleUnits) {
 		populateComponentIfErrorIs(_opts, err,
 			errs.ErrInvalidID, errs.ErrInvalidFloat,
-			errs.ErrInvalidNumber, errs.ErrInvalidDate)
+			errs.ErrInvalidNumber, errs.ErrInvalidDate,
+			errs.ErrInvalidEnum, errs.ErrIncompatibleUnits)
 		populateComponentIfErrorIsValidationError(_opts, err)
 		return unprocessableEntity(_opts)
 	}
 
+	if errors.Is(err, errs.ErrInvalidPayment) {
+		populateComponentIfErrorIs(_opts, err, errs.ErrInvalidPayment)
+		if _opts.message == "" {
+			_opts.message = err.Error()
+		}
+		return unprocessableEntity(_opts)
+	}
+
 	if errors.Is(err, errs.ErrDocumentAlreadyExists) {
 		populateComponentIfErrorIs(_opts, err, errs.ErrDocumentAlreadyExists)
 		return conflict(_opts)
 	}
 
+	if errors.Is(err, errs.ErrInsufficientStock) {
+		populateComponentIfErrorIs(_opts, err, errs.ErrInsufficientStock)
+		if _opts.message == "" {
+			_opts.message = "There isn't enough stock for this."
+		}
+		return conflict(_opts)
+	}
+
+	if errors.Is(err, errs.ErrInvalidTransition) {
+		populateComponentIfErrorIs(_opts, err, errs.ErrInvalidTransition)
+		if _opts.message == "" {
+			_opts.message = err.Error()
+		}
+		return conflict(_opts)
+	}
+
+	if errors.Is(err, errs.ErrMergeConflict) {
+		populateComponentIfErrorIs(_opts, err, errs.ErrMergeConflict)
+		if _opts.message == "" {
+			_opts.message = err.Error()
+		}
+		return conflict(_opts)
+	}
+
 	if errors.Is(err, errs.ErrForbidden) {
 		populateComponentIfErrorIs(_opts, err, errs.ErrForbidden)
 		return forbidden(_opts)
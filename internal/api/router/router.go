@@ -3,6 +3,7 @@ package router
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/omareloui/odinls/internal/api/handler"
 	"github.com/omareloui/odinls/internal/api/middleware"
@@ -36,31 +37,98 @@ func New(h handler.Handler) Router {
 	mux.Handle("GET /clients/{id}/edit", handle(h.GetEditClient))
 	mux.Handle("PUT /clients/{id}", handle(h.EditClient))
 	mux.Handle("POST /clients", handle(h.CreateClient))
+	mux.Handle("GET /clients/duplicates", handle(h.GetClientDuplicates))
+	mux.Handle("POST /clients/{id}/merge", handle(h.MergeClients))
 
 	mux.Handle("GET /materials", handle(h.GetMaterials))
+	mux.Handle("GET /materials/costing", handle(h.GetMaterialsCosting))
 	mux.Handle("GET /materials/{id}", handle(h.GetMaterial))
 	mux.Handle("GET /materials/{id}/edit", handle(h.GetEditMaterial))
 	mux.Handle("PUT /materials/{id}", handle(h.EditMaterial))
 	mux.Handle("POST /materials", handle(h.CreateMaterial))
+	mux.Handle("GET /materials/{id}/movements", handle(h.GetMaterialMovements))
+	mux.Handle("POST /materials/{id}/movements", handle(h.CreateMaterialMovement))
+	mux.Handle("GET /materials/{id}/hides", handle(h.GetMaterialHides))
+	mux.Handle("POST /materials/{id}/hides", handle(h.CreateMaterialHide))
+	mux.Handle("POST /materials/{id}/hides/{hideId}/scraps", handle(h.RecordHideScrap))
+	mux.Handle("GET /materials/{id}/suppliers", handle(h.GetMaterialSuppliers))
 
 	mux.Handle("GET /suppliers", handle(h.GetSuppliers))
 	mux.Handle("GET /suppliers/{id}", handle(h.GetSupplier))
 	mux.Handle("GET /suppliers/{id}/edit", handle(h.GetEditSupplier))
 	mux.Handle("PUT /suppliers/{id}", handle(h.EditSupplier))
 	mux.Handle("POST /suppliers", handle(h.CreateSupplier))
+	mux.Handle("GET /suppliers/{id}/purchases", handle(h.GetSupplierPurchaseOrders))
+	mux.Handle("GET /suppliers/{id}/prices", handle(h.GetSupplierPrices))
+	mux.Handle("POST /suppliers/{id}/prices", handle(h.SetSupplierPrice))
+	mux.Handle("DELETE /suppliers/{id}/prices/{materialId}", handle(h.RemoveSupplierPrice))
+	mux.Handle("GET /suppliers/{id}/scorecard", handle(h.GetSupplierScorecard))
+	mux.Handle("GET /suppliers/{id}/scorecard.csv", handle(h.GetSupplierScorecardCSV))
+
+	mux.Handle("GET /purchases", handle(h.GetPurchaseOrders))
+	mux.Handle("GET /purchases/{id}", handle(h.GetPurchaseOrder))
+	mux.Handle("POST /purchases", handle(h.CreatePurchaseOrder))
+	mux.Handle("PATCH /purchases/{id}/status", handle(h.TransitionPurchaseOrderStatus))
+	mux.Handle("GET /purchases/{id}/receive", handle(h.GetReceiveGoods))
+	mux.Handle("POST /purchases/{id}/receive", handle(h.ReceiveGoods))
 
 	mux.Handle("GET /products", handle(h.GetProducts))
 	mux.Handle("GET /products/{id}", handle(h.GetProduct))
 	mux.Handle("GET /products/{id}/edit", handle(h.GetEditProduct))
 	mux.Handle("PUT /products/{id}", handle(h.EditProduct))
 	mux.Handle("POST /products", handle(h.CreateProduct))
+	mux.Handle("GET /products/{id}/variants/{variantId}/tiers", handle(h.GetVariantTierPrices))
+	mux.Handle("POST /products/{id}/variants/{variantId}/tiers", handle(h.SetVariantTierPrices))
+	mux.Handle("GET /products/reviews", handle(h.GetPriceReviews))
+	mux.Handle("GET /products/capacity", handle(h.GetCapacities))
+	mux.Handle("POST /products/capacity", handle(h.GetShortfalls))
 
 	mux.Handle("GET /orders", handle(h.GetOrders))
 	mux.Handle("GET /orders/{id}", handle(h.GetOrder))
 	mux.Handle("GET /orders/{id}/edit", handle(h.GetEditOrder))
 	mux.Handle("PUT /orders/{id}", handle(h.EditOrder))
+	mux.Handle("PATCH /orders/{id}/status", handle(h.TransitionOrderStatus))
+	mux.Handle("POST /orders/{id}/items/{itemId}/promote", handle(h.PromoteCustomItem))
+	mux.Handle("PATCH /orders/{id}/items/{itemId}/progress", handle(h.UpdateItemProgress))
+	mux.Handle("PATCH /orders/{id}/items/{itemId}/craftsman", handle(h.AssignItemCraftsman))
+	mux.Handle("PATCH /orders/{id}/items/{itemId}/hides", handle(h.PickItemHides))
+	mux.Handle("GET /orders/{id}/invoice", handle(h.GetOrderInvoice))
+	mux.Handle("GET /orders/{id}/invoice.pdf", handle(h.GetOrderInvoicePDF))
+	mux.Handle("GET /orders/{id}/payments", handle(h.GetOrderPayments))
+	mux.Handle("POST /orders/{id}/payments", handle(h.RecordOrderPayment))
+	mux.Handle("POST /orders/{id}/refunds", handle(h.RecordOrderRefund))
+	mux.Handle("GET /orders/{id}/payments/{paymentId}/receipt", handle(h.GetPaymentReceipt))
+	mux.Handle("PUT /orders/{id}/plan", handle(h.SetOrderPaymentPlan))
+	mux.Handle("GET /receivables", handle(h.GetReceivables))
 	mux.Handle("POST /orders", handle(h.CreateOrder))
 
+	mux.Handle("GET /quotes", handle(h.GetQuotes))
+	mux.Handle("POST /quotes", handle(h.CreateQuote))
+	mux.Handle("GET /quotes/{id}", handle(h.GetQuote))
+	mux.Handle("GET /quotes/{id}/edit", handle(h.GetEditQuote))
+	mux.Handle("PUT /quotes/{id}", handle(h.EditQuote))
+	mux.Handle("PATCH /quotes/{id}/status", handle(h.TransitionQuoteStatus))
+	mux.Handle("POST /quotes/{id}/convert", handle(h.ConvertQuote))
+	mux.Handle("GET /quotes/{id}/document", handle(h.GetQuoteDocument))
+
+	mux.Handle("GET /me", handle(h.GetMe))
+	mux.Handle("GET /me/contact", handle(h.GetMyContact))
+	mux.Handle("GET /me/edit", handle(h.GetEditMyContact))
+	mux.Handle("PUT /me", handle(h.EditMyContact))
+	mux.Handle("GET /me/orders/{id}", handle(h.GetMyOrder))
+	mux.Handle("GET /me/orders/{id}/invoice", handle(h.GetMyOrderInvoice))
+	mux.Handle("GET /me/orders/{id}/invoice.pdf", handle(h.GetMyOrderInvoicePDF))
+	mux.Handle("GET /me/orders/{id}/payments/{paymentId}/receipt", handle(h.GetMyPaymentReceipt))
+
+	trackThrottle := middleware.ThrottleFailures(10, 15*time.Minute, http.StatusNotFound)
+	mux.Handle("GET /track", handlePub(h.GetTrackOrder))
+	mux.Handle("GET /track/{ref}", handlePub(h.TrackOrder, trackThrottle))
+
+	mux.Handle("GET /board", handle(h.GetBoard))
+
+	mux.Handle("GET /settings/costing", handle(h.GetCostingSettings))
+	mux.Handle("PUT /settings/costing", handle(h.EditCostingSettings))
+
 	mux.Handle("GET /unauthorized", handlePub(h.Unauthorized))
 
 	static(mux, []string{"styles", "js", "images"}, "./web/public")
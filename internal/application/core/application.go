@@ -1,11 +1,18 @@
 package application
 
 import (
+	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
 	"github.com/omareloui/odinls/internal/application/core/client"
+	"github.com/omareloui/odinls/internal/application/core/costing"
 	"github.com/omareloui/odinls/internal/application/core/counter"
+	"github.com/omareloui/odinls/internal/application/core/invoice"
 	"github.com/omareloui/odinls/internal/application/core/material"
 	"github.com/omareloui/odinls/internal/application/core/order"
 	"github.com/omareloui/odinls/internal/application/core/product"
+	"github.com/omareloui/odinls/internal/application/core/purchase"
+	"github.com/omareloui/odinls/internal/application/core/quote"
+	"github.com/omareloui/odinls/internal/application/core/reorder"
+	"github.com/omareloui/odinls/internal/application/core/stock"
 	"github.com/omareloui/odinls/internal/application/core/supplier"
 	"github.com/omareloui/odinls/internal/application/core/user"
 	"github.com/omareloui/odinls/internal/interfaces"
@@ -14,24 +21,57 @@ import (
 
 type Application struct {
 	ClientService   client.ClientService
+	CostingService  costing.CostingService
+	InvoiceService  invoice.InvoiceService
 	MaterialService material.MaterialService
 	OrderService    order.OrderService
 	ProductService  product.ProductService
+	PurchaseService purchase.PurchaseService
+	QuoteService    quote.QuoteService
+	ReorderService  reorder.ReorderService
+	StockService    stock.StockService
 	SupplierService supplier.SupplierService
 	UserService     user.UserService
 }
 
-func NewApplication(repo repository.Repository, validator interfaces.Validator, sanitizer interfaces.Sanitizer) *Application {
+func NewApplication(repo repository.Repository, validator interfaces.Validator, sanitizer interfaces.Sanitizer, notifier reorder.Notifier, reorderMaxLeadDays int) *Application {
 	counterService := counter.NewCounterService(repo)
 
-	productService := product.NewProductService(repo, validator, sanitizer, counterService)
+	costingService := costing.NewCostingService(repo, validator, sanitizer)
+	materialService := material.NewMaterialService(repo, validator, sanitizer)
+	userService := user.NewUserService(repo, validator, sanitizer)
+
+	productService := product.NewProductService(repo, validator, sanitizer, counterService, costingService, materialService, userService)
+
+	materialService.OnPriceChange(func(claims *jwtadapter.AccessClaims, mat *material.Material) error {
+		_, err := productService.RepriceByMaterial(claims, mat.ID)
+		return err
+	})
+
+	clientService := client.NewClientService(repo, validator, sanitizer)
+	orderService := order.NewOrderService(repo, productService, clientService, counterService, userService, validator, sanitizer)
+
+	stockService := stock.NewStockService(repo, materialService, productService, validator, sanitizer)
+	materialService.OnCreate(stockService.RecordOpeningBalance)
+	orderService.OnStatusChange(stockService.HandleOrderStatus)
+	orderService.OnItemProgress(stockService.HandleItemProgress)
+	orderService.OnEdit(stockService.HandleOrderEdit)
+
+	supplierService := supplier.NewSupplierService(repo, validator, sanitizer)
+	purchaseService := purchase.NewPurchaseService(repo, materialService, supplierService, stockService, validator, sanitizer)
 
 	return &Application{
-		ClientService:   client.NewClientService(repo, validator, sanitizer),
-		MaterialService: material.NewMaterialService(repo, validator, sanitizer),
-		OrderService:    order.NewOrderService(repo, productService, counterService, validator, sanitizer),
+		ClientService:   clientService,
+		CostingService:  costingService,
+		InvoiceService:  invoice.NewInvoiceService(orderService),
+		MaterialService: materialService,
+		OrderService:    orderService,
 		ProductService:  productService,
-		SupplierService: supplier.NewSupplierService(repo, validator, sanitizer),
-		UserService:     user.NewUserService(repo, validator, sanitizer),
+		PurchaseService: purchaseService,
+		QuoteService:    quote.NewQuoteService(repo, orderService, counterService, validator, sanitizer),
+		ReorderService:  reorder.NewReorderService(repo, notifier, reorderMaxLeadDays),
+		StockService:    stockService,
+		SupplierService: supplierService,
+		UserService:     userService,
 	}
 }
@@ -0,0 +1,233 @@
+package client
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+type MatchReasonEnum string
+
+const (
+	MatchPhone MatchReasonEnum = "PHONE"
+	MatchEmail MatchReasonEnum = "EMAIL"
+	MatchName  MatchReasonEnum = "NAME"
+)
+
+func (m MatchReasonEnum) View() string {
+	switch m {
+	case MatchPhone:
+		return "Same Phone Number"
+	case MatchEmail:
+		return "Same Email"
+	case MatchName:
+		return "Similar Name"
+	default:
+		return string(m)
+	}
+}
+
+// Duplicate is two clients that look like the same person, and why.
+type Duplicate struct {
+	Client  Client
+	Other   Client
+	Reasons []MatchReasonEnum
+}
+
+// FindDuplicates pairs the clients sharing a phone number or an email after
+// normalizing them, or having similar names.
+func FindDuplicates(clients []Client) []Duplicate {
+	type keys struct {
+		phones []string
+		emails []string
+		name   string
+	}
+
+	clientKeys := make([]keys, len(clients))
+	for i, cli := range clients {
+		k := keys{name: normalizeName(cli.Name)}
+		for _, phone := range cli.ContactInfo.PhoneNumbers {
+			if p := normalizePhone(phone); p != "" {
+				k.phones = append(k.phones, p)
+			}
+		}
+		for _, email := range cli.ContactInfo.Emails {
+			if e := normalizeEmail(email); e != "" {
+				k.emails = append(k.emails, e)
+			}
+		}
+		clientKeys[i] = k
+	}
+
+	duplicates := []Duplicate{}
+	for i := range clients {
+		for j := i + 1; j < len(clients); j++ {
+			a, b := clientKeys[i], clientKeys[j]
+
+			reasons := []MatchReasonEnum{}
+			if intersects(a.phones, b.phones) {
+				reasons = append(reasons, MatchPhone)
+			}
+			if intersects(a.emails, b.emails) {
+				reasons = append(reasons, MatchEmail)
+			}
+			if similarNames(a.name, b.name) {
+				reasons = append(reasons, MatchName)
+			}
+
+			if len(reasons) > 0 {
+				duplicates = append(duplicates, Duplicate{Client: clients[i], Other: clients[j], Reasons: reasons})
+			}
+		}
+	}
+
+	// The pairs with more in common are more likely to be the same person.
+	slices.SortStableFunc(duplicates, func(a, b Duplicate) int {
+		return len(b.Reasons) - len(a.Reasons)
+	})
+
+	return duplicates
+}
+
+// MergeContactInfo adds the other contact info to the contact info, skipping
+// the values it already has. A label that's already used gets a number.
+func MergeContactInfo(ci, other ContactInfo) ContactInfo {
+	return ContactInfo{
+		PhoneNumbers: mergeContacts(ci.PhoneNumbers, other.PhoneNumbers, normalizePhone),
+		Emails:       mergeContacts(ci.Emails, other.Emails, normalizeEmail),
+		Links:        mergeContacts(ci.Links, other.Links, strings.TrimSpace),
+		Locations:    mergeContacts(ci.Locations, other.Locations, strings.TrimSpace),
+	}
+}
+
+func mergeContacts(m, other map[string]string, normalize func(string) string) map[string]string {
+	if len(m) == 0 && len(other) == 0 {
+		return nil
+	}
+
+	merged := make(map[string]string, len(m)+len(other))
+	existing := map[string]bool{}
+	for label, value := range m {
+		merged[label] = value
+		existing[normalize(value)] = true
+	}
+
+	// Going over the labels in order so the numbered labels are the same
+	// every time.
+	labels := make([]string, 0, len(other))
+	for label := range other {
+		labels = append(labels, label)
+	}
+	slices.Sort(labels)
+
+	for _, label := range labels {
+		value := other[label]
+		normalized := normalize(value)
+		if normalized != "" && existing[normalized] {
+			continue
+		}
+		existing[normalized] = true
+
+		key := label
+		for n := 2; merged[key] != ""; n++ {
+			key = fmt.Sprintf("%s %d", label, n)
+		}
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// normalizePhone keeps the last 10 digits of the phone number so the same
+// number matches with or without the country code.
+func normalizePhone(phone string) string {
+	digits := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, phone)
+
+	if len(digits) < 7 {
+		return ""
+	}
+	if len(digits) > 10 {
+		digits = digits[len(digits)-10:]
+	}
+	return digits
+}
+
+// normalizeEmail lowercases the email and drops the "+tag" part, and the dots
+// of the gmail addresses since gmail ignores them.
+func normalizeEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return email
+	}
+	local, _, _ = strings.Cut(local, "+")
+	if domain == "gmail.com" || domain == "googlemail.com" {
+		local = strings.ReplaceAll(local, ".", "")
+		domain = "gmail.com"
+	}
+	return local + "@" + domain
+}
+
+// normalizeName lowercases the name and keeps its words sorted, so "Doe John"
+// matches "John Doe".
+func normalizeName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	slices.Sort(words)
+	return strings.Join(words, " ")
+}
+
+// similarNames tells if the normalized names are a typo or two away from each
+// other.
+func similarNames(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+
+	maxDistance := 1
+	if min(len([]rune(a)), len([]rune(b))) >= 10 {
+		maxDistance = 2
+	}
+	return levenshtein(a, b) <= maxDistance
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func intersects(a, b []string) bool {
+	for _, v := range a {
+		if slices.Contains(b, v) {
+			return true
+		}
+	}
+	return false
+}
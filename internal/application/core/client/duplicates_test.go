@@ -0,0 +1,164 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	phone := func(p string) ContactInfo {
+		return ContactInfo{PhoneNumbers: map[string]string{"Mobile": p}}
+	}
+	email := func(e string) ContactInfo {
+		return ContactInfo{Emails: map[string]string{"Personal": e}}
+	}
+
+	tests := []struct {
+		name        string
+		a           Client
+		b           Client
+		wantReasons []MatchReasonEnum
+	}{
+		{
+			"phone with and without the country code",
+			Client{Name: "Ahmed Ali", ContactInfo: phone("+20 100 123 4567")},
+			Client{Name: "Mona Hassan", ContactInfo: phone("01001234567")},
+			[]MatchReasonEnum{MatchPhone},
+		},
+		{
+			"gmail dots and tags",
+			Client{Name: "Ahmed Ali", ContactInfo: email("Ahmed.Ali+shop@Gmail.com")},
+			Client{Name: "Mona Hassan", ContactInfo: email("ahmedali@googlemail.com")},
+			[]MatchReasonEnum{MatchEmail},
+		},
+		{
+			"dots in other domains count",
+			Client{Name: "Ahmed Ali", ContactInfo: email("ahmed.ali@example.com")},
+			Client{Name: "Mona Hassan", ContactInfo: email("ahmedali@example.com")},
+			nil,
+		},
+		{
+			"names in a different order",
+			Client{Name: "Ali Ahmed"},
+			Client{Name: "ahmed ali"},
+			[]MatchReasonEnum{MatchName},
+		},
+		{
+			"a typo in a short name",
+			Client{Name: "Omar Adel"},
+			Client{Name: "Omar Adl"},
+			[]MatchReasonEnum{MatchName},
+		},
+		{
+			"two typos in a short name",
+			Client{Name: "Omar Adel"},
+			Client{Name: "Omar Edal"},
+			nil,
+		},
+		{
+			"two typos in a long name",
+			Client{Name: "Mohamed Abdelrahman"},
+			Client{Name: "Mohamad Abdelrahmen"},
+			[]MatchReasonEnum{MatchName},
+		},
+		{
+			"too short phone numbers are ignored",
+			Client{Name: "Ahmed Ali", ContactInfo: phone("123")},
+			Client{Name: "Mona Hassan", ContactInfo: phone("123")},
+			nil,
+		},
+		{
+			"all the reasons",
+			Client{Name: "Ahmed Ali", ContactInfo: ContactInfo{
+				PhoneNumbers: map[string]string{"Mobile": "01001234567"},
+				Emails:       map[string]string{"Personal": "ahmed@example.com"},
+			}},
+			Client{Name: "Ahmad Ali", ContactInfo: ContactInfo{
+				PhoneNumbers: map[string]string{"Work": "0100 123 4567"},
+				Emails:       map[string]string{"Work": "AHMED@example.com"},
+			}},
+			[]MatchReasonEnum{MatchPhone, MatchEmail, MatchName},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			duplicates := FindDuplicates([]Client{tt.a, tt.b})
+			if tt.wantReasons == nil {
+				assert.Empty(t, duplicates)
+				return
+			}
+			if assert.Len(t, duplicates, 1) {
+				assert.Equal(t, tt.wantReasons, duplicates[0].Reasons)
+			}
+		})
+	}
+}
+
+func TestFindDuplicatesMostInCommonFirst(t *testing.T) {
+	clients := []Client{
+		{ID: "1", Name: "Omar Adel"},
+		{ID: "2", Name: "Omar Adl"},
+		{ID: "3", Name: "Mona Hassan", ContactInfo: ContactInfo{Emails: map[string]string{"Personal": "mona@example.com"}}},
+		{ID: "4", Name: "Mona Hasan", ContactInfo: ContactInfo{Emails: map[string]string{"Personal": "mona@example.com"}}},
+	}
+
+	duplicates := FindDuplicates(clients)
+	if assert.Len(t, duplicates, 2) {
+		assert.Equal(t, []string{"3", "4"}, []string{duplicates[0].Client.ID, duplicates[0].Other.ID})
+		assert.Equal(t, []string{"1", "2"}, []string{duplicates[1].Client.ID, duplicates[1].Other.ID})
+	}
+}
+
+func TestMergeContactInfo(t *testing.T) {
+	tests := []struct {
+		name  string
+		ci    ContactInfo
+		other ContactInfo
+		want  ContactInfo
+	}{
+		{
+			"empty",
+			ContactInfo{},
+			ContactInfo{},
+			ContactInfo{},
+		},
+		{
+			"new labels are added",
+			ContactInfo{PhoneNumbers: map[string]string{"Mobile": "01001234567"}},
+			ContactInfo{Emails: map[string]string{"Personal": "ahmed@example.com"}},
+			ContactInfo{
+				PhoneNumbers: map[string]string{"Mobile": "01001234567"},
+				Emails:       map[string]string{"Personal": "ahmed@example.com"},
+			},
+		},
+		{
+			"the same values are skipped after normalizing",
+			ContactInfo{
+				PhoneNumbers: map[string]string{"Mobile": "01001234567"},
+				Emails:       map[string]string{"Personal": "ahmed.ali@gmail.com"},
+			},
+			ContactInfo{
+				PhoneNumbers: map[string]string{"Work": "+20 100 123 4567"},
+				Emails:       map[string]string{"Work": "AhmedAli+orders@gmail.com"},
+			},
+			ContactInfo{
+				PhoneNumbers: map[string]string{"Mobile": "01001234567"},
+				Emails:       map[string]string{"Personal": "ahmed.ali@gmail.com"},
+			},
+		},
+		{
+			"used labels get a number",
+			ContactInfo{Locations: map[string]string{"Home": "Cairo", "Home 2": "Giza"}},
+			ContactInfo{Locations: map[string]string{"Home": "Alexandria", "Work": "Cairo"}},
+			ContactInfo{Locations: map[string]string{"Home": "Cairo", "Home 2": "Giza", "Home 3": "Alexandria"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MergeContactInfo(tt.ci, tt.other))
+		})
+	}
+}
@@ -1,7 +1,13 @@
 package client
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
 	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+	"github.com/omareloui/odinls/internal/application/core/product"
 	"github.com/omareloui/odinls/internal/errs"
 	"github.com/omareloui/odinls/internal/interfaces"
 )
@@ -50,6 +56,10 @@ func (s *clientService) CreateClient(claims *jwtadapter.AccessClaims, client *Cl
 		return nil, err
 	}
 
+	if client.PriceTier != "" && !slices.Contains(product.PriceTiersEnums(), client.PriceTier) {
+		return nil, errs.ErrInvalidEnum
+	}
+
 	return s.repo.CreateClient(client)
 }
 
@@ -67,9 +77,180 @@ func (s *clientService) UpdateClientByID(claims *jwtadapter.AccessClaims, id str
 		return nil, err
 	}
 
+	if client.PriceTier != "" && !slices.Contains(product.PriceTiersEnums(), client.PriceTier) {
+		return nil, errs.ErrInvalidEnum
+	}
+
 	return s.repo.UpdateClientByID(id, client)
 }
 
+func (s *clientService) GetDuplicateClients(claims *jwtadapter.AccessClaims) ([]Duplicate, error) {
+	if claims == nil || !claims.Role.IsModerator() {
+		return nil, errs.ErrForbidden
+	}
+
+	clients, err := s.repo.GetClients()
+	if err != nil {
+		return nil, err
+	}
+
+	return FindDuplicates(clients), nil
+}
+
+func (s *clientService) GetClientMerges(claims *jwtadapter.AccessClaims, clientID string) ([]Merge, error) {
+	if claims == nil || !claims.Role.IsModerator() {
+		return nil, errs.ErrForbidden
+	}
+
+	return s.repo.GetClientMerges(clientID)
+}
+
+// MergeClients moves the duplicate's orders and quotes to the survivor, adds
+// its contact info to the survivor's, records the merge, then deletes the
+// duplicate. The duplicate is only deleted once the survivor has everything of
+// it, so a failed merge can be retried.
+func (s *clientService) MergeClients(claims *jwtadapter.AccessClaims, survivorID, duplicateID string) (*Client, error) {
+	if claims == nil || !claims.Role.IsAdmin() {
+		return nil, errs.ErrForbidden
+	}
+
+	if survivorID == duplicateID {
+		return nil, fmt.Errorf("%w: can't merge a client into itself", errs.ErrMergeConflict)
+	}
+
+	survivor, err := s.repo.GetClientByID(survivorID)
+	if err != nil {
+		return nil, err
+	}
+	duplicate, err := s.repo.GetClientByID(duplicateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if survivor.UserID != "" && duplicate.UserID != "" && survivor.UserID != duplicate.UserID {
+		return nil, fmt.Errorf("%w: both clients are linked to different users", errs.ErrMergeConflict)
+	}
+
+	merged := *survivor
+	merged.ContactInfo = MergeContactInfo(survivor.ContactInfo, duplicate.ContactInfo)
+	if merged.PriceTier == "" {
+		merged.PriceTier = duplicate.PriceTier
+	}
+	if duplicate.Notes != "" && duplicate.Notes != merged.Notes {
+		merged.Notes = strings.TrimSpace(merged.Notes + "\n" + duplicate.Notes)
+	}
+
+	moved, err := s.repo.ReassignClientOrders(duplicate.ID, survivor.ID)
+	if err != nil {
+		return nil, err
+	}
+	quotesMoved, err := s.repo.ReassignClientQuotes(duplicate.ID, survivor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.UpdateClientByID(survivor.ID, &merged)
+	if err != nil {
+		return nil, err
+	}
+
+	// A user can be linked to one client only, so the survivor takes the
+	// duplicate's user after it's unlinked from the duplicate.
+	if survivor.UserID == "" && duplicate.UserID != "" {
+		if _, err := s.repo.SetClientUser(duplicate.ID, ""); err != nil {
+			return nil, err
+		}
+		updated, err = s.repo.SetClientUser(survivor.ID, duplicate.UserID)
+		if err != nil {
+			if _, rerr := s.repo.SetClientUser(duplicate.ID, duplicate.UserID); rerr != nil {
+				return nil, errors.Join(err, rerr)
+			}
+			return nil, err
+		}
+	}
+
+	_, err = s.repo.CreateClientMerge(&Merge{
+		SurvivorID:  survivor.ID,
+		Duplicate:   *duplicate,
+		OrdersMoved: moved,
+		QuotesMoved: quotesMoved,
+		MergedBy:    claims.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.DeleteClientByID(duplicate.ID); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// GetMyClient gets the client record of the logged in user, the staff don't
+// have one. A user that isn't linked to a client yet gets a new client, it's
+// never linked to an existing client by its email as the email isn't
+// verified. The staff link the two by merging the existing client into it.
+func (s *clientService) GetMyClient(claims *jwtadapter.AccessClaims) (*Client, error) {
+	if claims == nil || claims.ID == "" || claims.Role.IsModerator() || claims.IsCraftsman() {
+		return nil, errs.ErrForbidden
+	}
+
+	cli, err := s.repo.GetClientByUserID(claims.ID)
+	if !errors.Is(err, errs.ErrDocumentNotFound) {
+		return cli, err
+	}
+
+	return s.createMyClient(claims, strings.ToLower(strings.TrimSpace(claims.Email)))
+}
+
+func (s *clientService) createMyClient(claims *jwtadapter.AccessClaims, email string) (*Client, error) {
+	cli := &Client{UserID: claims.ID, Name: claims.Name.FullName()}
+	cli.ContactInfo.SetPrimary(PrimaryContact{Email: email})
+
+	if err := s.sanitizeClient(cli); err != nil {
+		return nil, err
+	}
+	if err := s.validator.Validate(cli); err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.CreateClient(cli)
+	if !errors.Is(err, errs.ErrDocumentAlreadyExists) {
+		return created, err
+	}
+
+	// Either the user got linked to a client in the meantime, or another
+	// client has the same name.
+	if linked, err := s.repo.GetClientByUserID(claims.ID); err == nil {
+		return linked, nil
+	}
+	if email == "" {
+		return nil, errs.ErrDocumentAlreadyExists
+	}
+	cli.Name = fmt.Sprintf("%s (%s)", cli.Name, email)
+	return s.repo.CreateClient(cli)
+}
+
+func (s *clientService) UpdateMyContactInfo(claims *jwtadapter.AccessClaims, contact *PrimaryContact) (*Client, error) {
+	cli, err := s.GetMyClient(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sanitizer.SanitizeStruct(contact); err != nil {
+		return nil, errs.ErrSanitizer
+	}
+	if err := s.validator.Validate(contact); err != nil {
+		return nil, err
+	}
+
+	contactInfo := cli.ContactInfo
+	contactInfo.SetPrimary(*contact)
+
+	return s.repo.UpdateClientContactInfo(cli.ID, contactInfo)
+}
+
 func (s *clientService) sanitizeClient(cli *Client) error {
 	err := s.sanitizer.SanitizeStruct(cli)
 	if err != nil {
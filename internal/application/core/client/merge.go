@@ -0,0 +1,18 @@
+package client
+
+import "time"
+
+// Merge is the record of merging a duplicate client into another one, it
+// keeps the duplicate as it was before getting deleted.
+type Merge struct {
+	ID string `json:"id" bson:"_id,omitempty"`
+
+	SurvivorID  string `json:"survivor_id" bson:"survivor"`
+	Duplicate   Client `json:"duplicate" bson:"duplicate"`
+	OrdersMoved int64  `json:"orders_moved" bson:"orders_moved"`
+	QuotesMoved int64  `json:"quotes_moved" bson:"quotes_moved"`
+	MergedBy    string `json:"merged_by" bson:"merged_by"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
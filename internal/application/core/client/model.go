@@ -3,6 +3,7 @@ package client
 import (
 	"time"
 
+	"github.com/omareloui/odinls/internal/application/core/product"
 	"github.com/omareloui/odinls/internal/application/core/user"
 )
 
@@ -14,6 +15,9 @@ type Client struct {
 	Notes              string      `json:"notes" formfield:"notes" conform:"trim" bson:"notes,omitempty"`
 	ContactInfo        ContactInfo `json:"contact_info" formfield:"contact_info" bson:"contact_info,omitempty"`
 	WholesaleAsDefault bool        `json:"wholesale_as_default" formfield:"wholesale_as_default" bson:"wholesale_as_default" validate:"boolean"`
+	// PriceTier is the prices the client's orders get, it overrides selling
+	// as wholesale by default.
+	PriceTier product.PriceTierEnum `json:"price_tier" formfield:"price_tier" bson:"price_tier,omitempty" conform:"trim,upper"`
 
 	CreatedAt time.Time `json:"created_at" bson:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
@@ -34,3 +38,57 @@ func (c Client) HasContactInfo() bool {
 		len(c.ContactInfo.Links) > 0 ||
 		len(c.ContactInfo.PhoneNumbers) > 0
 }
+
+// Tier is the price tier the client's orders are priced by.
+func (c Client) Tier() product.PriceTierEnum {
+	if c.PriceTier != "" {
+		return c.PriceTier
+	}
+	if c.WholesaleAsDefault {
+		return product.PriceTierWholesale
+	}
+	return product.PriceTierRetail
+}
+
+// PrimaryContactKey is the label of the contact info the client edits
+// themself from their portal.
+const PrimaryContactKey = "Primary"
+
+// PrimaryContact is the contact info the client can edit themself, it's kept
+// under the PrimaryContactKey label of each of the contact info maps.
+type PrimaryContact struct {
+	Phone    string `formfield:"phone" conform:"num" validate:"omitempty,min=3,max=255"`
+	Email    string `formfield:"email" conform:"email" validate:"omitempty,email"`
+	Link     string `formfield:"link" conform:"trim" validate:"omitempty,http_url"`
+	Location string `formfield:"location" conform:"trim" validate:"omitempty,max=255"`
+}
+
+func (c Client) PrimaryContact() PrimaryContact {
+	return PrimaryContact{
+		Phone:    c.ContactInfo.PhoneNumbers[PrimaryContactKey],
+		Email:    c.ContactInfo.Emails[PrimaryContactKey],
+		Link:     c.ContactInfo.Links[PrimaryContactKey],
+		Location: c.ContactInfo.Locations[PrimaryContactKey],
+	}
+}
+
+// SetPrimary sets the primary contact info keeping the other labels as they
+// are, an empty value removes its label.
+func (ci *ContactInfo) SetPrimary(pc PrimaryContact) {
+	ci.PhoneNumbers = setPrimary(ci.PhoneNumbers, pc.Phone)
+	ci.Emails = setPrimary(ci.Emails, pc.Email)
+	ci.Links = setPrimary(ci.Links, pc.Link)
+	ci.Locations = setPrimary(ci.Locations, pc.Location)
+}
+
+func setPrimary(m map[string]string, value string) map[string]string {
+	if value == "" {
+		delete(m, PrimaryContactKey)
+		return m
+	}
+	if m == nil {
+		m = map[string]string{}
+	}
+	m[PrimaryContactKey] = value
+	return m
+}
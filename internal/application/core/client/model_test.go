@@ -0,0 +1,26 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/omareloui/odinls/internal/application/core/product"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTier(t *testing.T) {
+	tests := []struct {
+		name   string
+		client Client
+		want   product.PriceTierEnum
+	}{
+		{"retail by default", Client{}, product.PriceTierRetail},
+		{"wholesale as default", Client{WholesaleAsDefault: true}, product.PriceTierWholesale},
+		{"price tier overrides wholesale as default", Client{WholesaleAsDefault: true, PriceTier: product.PriceTierDistributor}, product.PriceTierDistributor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.client.Tier())
+		})
+	}
+}
@@ -3,6 +3,15 @@ package client
 type ClientRepository interface {
 	GetClients() ([]Client, error)
 	GetClientByID(id string) (*Client, error)
+	GetClientByUserID(userID string) (*Client, error)
 	CreateClient(client *Client) (*Client, error)
 	UpdateClientByID(id string, client *Client) (*Client, error)
+	SetClientUser(id, userID string) (*Client, error)
+	UpdateClientContactInfo(id string, contactInfo ContactInfo) (*Client, error)
+	DeleteClientByID(id string) error
+
+	ReassignClientOrders(fromID, toID string) (int64, error)
+	ReassignClientQuotes(fromID, toID string) (int64, error)
+	CreateClientMerge(merge *Merge) (*Merge, error)
+	GetClientMerges(survivorID string) ([]Merge, error)
 }
@@ -7,4 +7,9 @@ type ClientService interface {
 	GetClientByID(claims *jwtadapter.AccessClaims, id string) (*Client, error)
 	CreateClient(claims *jwtadapter.AccessClaims, client *Client) (*Client, error)
 	UpdateClientByID(claims *jwtadapter.AccessClaims, id string, client *Client) (*Client, error)
+	GetDuplicateClients(claims *jwtadapter.AccessClaims) ([]Duplicate, error)
+	GetClientMerges(claims *jwtadapter.AccessClaims, clientID string) ([]Merge, error)
+	MergeClients(claims *jwtadapter.AccessClaims, survivorID, duplicateID string) (*Client, error)
+	GetMyClient(claims *jwtadapter.AccessClaims) (*Client, error)
+	UpdateMyContactInfo(claims *jwtadapter.AccessClaims, contact *PrimaryContact) (*Client, error)
 }
@@ -0,0 +1,29 @@
+package costing
+
+// MaterialCostingEnum is how a material's unit cost is derived from the lots
+// it was received in.
+type MaterialCostingEnum string
+
+const (
+	MaterialCostingLastPrice       MaterialCostingEnum = "LAST_PRICE"
+	MaterialCostingWeightedAverage MaterialCostingEnum = "WEIGHTED_AVERAGE"
+	MaterialCostingFIFO            MaterialCostingEnum = "FIFO"
+)
+
+func (m MaterialCostingEnum) View() string {
+	v := map[MaterialCostingEnum]string{
+		MaterialCostingLastPrice:       "Last Price",
+		MaterialCostingWeightedAverage: "Weighted Average",
+		MaterialCostingFIFO:            "FIFO Lots",
+	}[m]
+	if v == "" {
+		return MaterialCostingLastPrice.View()
+	}
+	return v
+}
+
+func MaterialCostingEnums() []MaterialCostingEnum {
+	return []MaterialCostingEnum{
+		MaterialCostingLastPrice, MaterialCostingWeightedAverage, MaterialCostingFIFO,
+	}
+}
@@ -0,0 +1,64 @@
+package costing
+
+import (
+	"errors"
+	"slices"
+
+	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+	"github.com/omareloui/odinls/internal/errs"
+	"github.com/omareloui/odinls/internal/interfaces"
+)
+
+type costingService struct {
+	repo      CostingRepository
+	validator interfaces.Validator
+	sanitizer interfaces.Sanitizer
+}
+
+func NewCostingService(repo CostingRepository, validator interfaces.Validator, sanitizer interfaces.Sanitizer) *costingService {
+	return &costingService{
+		repo:      repo,
+		validator: validator,
+		sanitizer: sanitizer,
+	}
+}
+
+func (s *costingService) GetSettings(claims *jwtadapter.AccessClaims) (*Settings, error) {
+	if claims == nil {
+		return nil, errs.ErrForbidden
+	}
+
+	settings, err := s.repo.GetCostingSettings()
+	if err != nil {
+		if errors.Is(err, errs.ErrDocumentNotFound) {
+			return DefaultSettings(), nil
+		}
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func (s *costingService) UpdateSettings(claims *jwtadapter.AccessClaims, settings *Settings) (*Settings, error) {
+	if claims == nil || !claims.Role.IsAdmin() {
+		return nil, errs.ErrForbidden
+	}
+
+	err := s.sanitizer.SanitizeStruct(settings)
+	if err != nil {
+		return nil, errs.ErrSanitizer
+	}
+
+	if err := s.validator.Validate(settings); err != nil {
+		return nil, err
+	}
+
+	if settings.MaterialCostingMethod == "" {
+		settings.MaterialCostingMethod = MaterialCostingLastPrice
+	}
+	if !slices.Contains(MaterialCostingEnums(), settings.MaterialCostingMethod) {
+		return nil, errs.ErrInvalidEnum
+	}
+
+	return s.repo.SaveCostingSettings(settings)
+}
@@ -0,0 +1,64 @@
+// Package costing holds the rates used to derive the products' prices from
+// their materials and the time it takes to craft them.
+package costing
+
+import "time"
+
+type Settings struct {
+	ID string `json:"id" bson:"_id,omitempty" formfield:"-"`
+
+	// HourlyRate is the rate paid for the crafting time, a variant's default
+	// craftsman's own rate overrides it.
+	HourlyRate float64 `json:"hourly_rate" bson:"hourly_rate" formfield:"hourly_rate" validate:"required,gt=0"`
+
+	MonthlyFixedCosts float64 `json:"monthly_fixed_costs" bson:"monthly_fixed_costs" formfield:"monthly_fixed_costs" validate:"min=0"`
+	MonthlyWorkHours  float64 `json:"monthly_work_hours" bson:"monthly_work_hours" formfield:"monthly_work_hours" validate:"required,gt=0"`
+
+	// The percentages are of the cost, e.g. 100 doubles it.
+	IncalculableCostsPercentage float64 `json:"incalculable_costs_percentage" bson:"incalculable_costs_percentage" formfield:"incalculable_costs_percentage" validate:"min=0"`
+	RetailProfitPercentage      float64 `json:"retail_profit_percentage" bson:"retail_profit_percentage" formfield:"retail_profit_percentage" validate:"min=0"`
+	WholesaleProfitPercentage   float64 `json:"wholesale_profit_percentage" bson:"wholesale_profit_percentage" formfield:"wholesale_profit_percentage" validate:"min=0"`
+
+	// MinMarginPercentage is the lowest margin of the price before a variant
+	// needs its price reviewed.
+	MinMarginPercentage float64 `json:"min_margin_percentage" bson:"min_margin_percentage" formfield:"min_margin_percentage" validate:"min=0,max=100"`
+
+	// MaterialCostingMethod is how the materials' unit costs are calculated
+	// for the products' costs.
+	MaterialCostingMethod MaterialCostingEnum `json:"material_costing_method" bson:"material_costing_method,omitempty" formfield:"material_costing_method" conform:"trim,upper"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// DefaultSettings are used until an admin saves the settings for the first
+// time.
+func DefaultSettings() *Settings {
+	return &Settings{
+		HourlyRate:                  60,
+		MonthlyFixedCosts:           6000,
+		MonthlyWorkHours:            176,
+		IncalculableCostsPercentage: 5,
+		RetailProfitPercentage:      100,
+		WholesaleProfitPercentage:   50,
+		MinMarginPercentage:         20,
+		MaterialCostingMethod:       MaterialCostingLastPrice,
+	}
+}
+
+func (s *Settings) HourlyFixedCosts() float64 {
+	if s.MonthlyWorkHours == 0 {
+		return 0
+	}
+	return s.MonthlyFixedCosts / s.MonthlyWorkHours
+}
+
+// WithHourlyRate returns a copy of the settings using the given hourly rate,
+// it's used for the craftsmen with their own rate.
+func (s *Settings) WithHourlyRate(rate float64) *Settings {
+	c := *s
+	if rate > 0 {
+		c.HourlyRate = rate
+	}
+	return &c
+}
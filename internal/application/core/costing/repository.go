@@ -0,0 +1,6 @@
+package costing
+
+type CostingRepository interface {
+	GetCostingSettings() (*Settings, error)
+	SaveCostingSettings(settings *Settings) (*Settings, error)
+}
@@ -0,0 +1,8 @@
+package costing
+
+import jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+
+type CostingService interface {
+	GetSettings(claims *jwtadapter.AccessClaims) (*Settings, error)
+	UpdateSettings(claims *jwtadapter.AccessClaims, settings *Settings) (*Settings, error)
+}
@@ -30,3 +30,11 @@ func (s *counterService) AddOneToOrder(claims *jwtadapter.AccessClaims) (uint, e
 
 	return s.repo.AddOneToOrder()
 }
+
+func (s *counterService) AddOneToQuote(claims *jwtadapter.AccessClaims) (uint, error) {
+	if claims == nil || !claims.IsCraftsman() || !claims.Role.IsAdmin() {
+		return 0, errs.ErrForbidden
+	}
+
+	return s.repo.AddOneToQuote()
+}
@@ -9,6 +9,7 @@ type ProductsCodes map[string]uint8
 type Counter struct {
 	ID            string        `json:"id" bson:"_id,omitempty"`
 	OrdersNumber  uint          `json:"orders_number" bson:"orders_number,omitempty"`
+	QuotesNumber  uint          `json:"quotes_number" bson:"quotes_number,omitempty"`
 	ProductsCodes ProductsCodes `json:"products_codes" bson:"products_codes,omitempty" validate:"required,dive,keys,required,min=3,max=255,not_blank,endkeys,required"`
 
 	CreatedAt time.Time `json:"created_at" bson:"created_at"`
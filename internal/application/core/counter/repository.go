@@ -3,4 +3,5 @@ package counter
 type CounterRepository interface {
 	AddOneToProduct(category string) (uint8, error)
 	AddOneToOrder() (uint, error)
+	AddOneToQuote() (uint, error)
 }
@@ -5,4 +5,5 @@ import jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
 type CounterService interface {
 	AddOneToProduct(claims *jwtadapter.AccessClaims, category string) (uint8, error)
 	AddOneToOrder(claims *jwtadapter.AccessClaims) (uint, error)
+	AddOneToQuote(claims *jwtadapter.AccessClaims) (uint, error)
 }
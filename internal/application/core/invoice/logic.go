@@ -0,0 +1,64 @@
+package invoice
+
+import (
+	"io"
+
+	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+	"github.com/omareloui/odinls/internal/application/core/order"
+	"github.com/omareloui/odinls/internal/errs"
+)
+
+type invoiceService struct {
+	orderService order.OrderService
+}
+
+func NewInvoiceService(orderService order.OrderService) *invoiceService {
+	return &invoiceService{orderService: orderService}
+}
+
+func (s *invoiceService) GetOrderInvoice(claims *jwtadapter.AccessClaims, orderID string) (*Invoice, error) {
+	ord, err := s.orderService.GetOrderByID(claims, orderID, order.WithPopulatedClient)
+	if err != nil {
+		return nil, err
+	}
+	return FromOrder(ord), nil
+}
+
+// GetMyOrderInvoice gets the invoice of one of the logged in user's orders.
+func (s *invoiceService) GetMyOrderInvoice(claims *jwtadapter.AccessClaims, orderID string) (*Invoice, error) {
+	ord, err := s.orderService.GetMyOrder(claims, orderID, order.WithPopulatedClient)
+	if err != nil {
+		return nil, err
+	}
+	return FromOrder(ord), nil
+}
+
+func (s *invoiceService) GetPaymentReceipt(claims *jwtadapter.AccessClaims, orderID, paymentID string) (*Receipt, error) {
+	ord, err := s.orderService.GetOrderByID(claims, orderID, order.WithPopulatedClient)
+	if err != nil {
+		return nil, err
+	}
+	return receiptOf(ord, paymentID)
+}
+
+// GetMyPaymentReceipt gets the receipt of a payment on one of the logged in
+// user's orders.
+func (s *invoiceService) GetMyPaymentReceipt(claims *jwtadapter.AccessClaims, orderID, paymentID string) (*Receipt, error) {
+	ord, err := s.orderService.GetMyOrder(claims, orderID, order.WithPopulatedClient)
+	if err != nil {
+		return nil, err
+	}
+	return receiptOf(ord, paymentID)
+}
+
+func receiptOf(ord *order.Order, paymentID string) (*Receipt, error) {
+	payment, ok := ord.PaymentByID(paymentID)
+	if !ok {
+		return nil, errs.ErrDocumentNotFound
+	}
+	return NewReceipt(ord, payment), nil
+}
+
+func (s *invoiceService) RenderPDF(inv *Invoice, w io.Writer) error {
+	return renderPDF(inv, w)
+}
@@ -0,0 +1,122 @@
+// Package invoice builds the printable invoices of the orders
+package invoice
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/omareloui/odinls/internal/application/core/order"
+)
+
+type Invoice struct {
+	OrderID     string
+	OrderNumber uint
+	Ref         string
+	Status      order.StatusEnum
+
+	IssuanceDate time.Time
+	DueDate      time.Time
+
+	ClientName    string
+	ClientPhones  []string
+	ClientEmails  []string
+	ClientAddress []string
+
+	Lines  []Line
+	Addons []order.AppliedPriceAddon
+
+	Payments []Payment
+
+	Subtotal  float64
+	Total     float64
+	Paid      float64
+	Remaining float64
+	// Credit is what the client paid over the total.
+	Credit float64
+}
+
+type Line struct {
+	SKU         string
+	Description string
+	Quantity    uint16
+	UnitPrice   float64
+	Total       float64
+}
+
+type Payment struct {
+	Date      time.Time
+	Amount    float64
+	Method    order.PaymentMethodEnum
+	Reference string
+	Reason    string
+}
+
+func (p Payment) Label() string {
+	label := fmt.Sprintf("%s %s", p.Date.Format(time.DateOnly), p.Method.View())
+	if p.Amount < 0 {
+		label += " Refund"
+	}
+	if p.Reference != "" {
+		label += fmt.Sprintf(" (%s)", p.Reference)
+	}
+	return label
+}
+
+func FromOrder(ord *order.Order) *Invoice {
+	inv := &Invoice{
+		OrderID:      ord.ID,
+		OrderNumber:  ord.Number,
+		Ref:          ord.RefView(),
+		Status:       ord.Status,
+		IssuanceDate: ord.Timeline.IssuanceDate,
+		DueDate:      ord.Timeline.DueDate,
+		ClientName:   ord.CustomerName,
+		Addons:       ord.AppliedPriceAddons(),
+		Subtotal:     ord.Subtotal(),
+		Total:        ord.TotalPrice(),
+		Paid:         ord.Paid(),
+		Remaining:    ord.RemainingAmount(),
+		Credit:       ord.Credit(),
+	}
+
+	if ord.Client != nil {
+		inv.ClientName = ord.Client.Name
+		inv.ClientPhones = mapValues(ord.Client.ContactInfo.PhoneNumbers)
+		inv.ClientEmails = mapValues(ord.Client.ContactInfo.Emails)
+		inv.ClientAddress = mapValues(ord.Client.ContactInfo.Locations)
+	}
+
+	for _, item := range ord.Items {
+		desc := item.Snapshot.ProductName
+		if item.Snapshot.VariantName != "" {
+			desc += " - " + item.Snapshot.VariantName
+		}
+		inv.Lines = append(inv.Lines, Line{
+			SKU:         item.Snapshot.SKU,
+			Description: desc,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice(),
+			Total:       item.TotalPrice(),
+		})
+	}
+
+	for _, payment := range ord.Payments {
+		inv.Payments = append(inv.Payments, Payment{
+			Date:      payment.Date,
+			Amount:    payment.Amount,
+			Method:    payment.Method,
+			Reference: payment.Reference,
+			Reason:    payment.Reason,
+		})
+	}
+
+	return inv
+}
+
+func mapValues(m map[string]string) []string {
+	values := make([]string, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
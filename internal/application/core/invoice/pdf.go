@@ -0,0 +1,138 @@
+package invoice
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/omareloui/odinls/internal/adapters/pdf"
+	"github.com/omareloui/odinls/internal/application/core/order"
+)
+
+const (
+	pdfMargin     = 50
+	pdfLineHeight = 16
+	pdfFontSize   = 10
+
+	pdfQtyX   = 360
+	pdfPriceX = 450
+	pdfTotalX = pdf.PageWidth - pdfMargin
+)
+
+func FormatMoney(v float64) string {
+	return "E£ " + strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func AddonLabel(addon order.AppliedPriceAddon) string {
+	if addon.IsPercentage {
+		return fmt.Sprintf("%s (%s%%)", addon.Kind.View(), strconv.FormatFloat(addon.Amount, 'f', -1, 64))
+	}
+	return addon.Kind.View()
+}
+
+type pdfWriter struct {
+	doc  *pdf.Document
+	page *pdf.Page
+	y    float64
+}
+
+func (w *pdfWriter) newPage() {
+	w.page = w.doc.AddPage()
+	w.y = pdfMargin
+}
+
+// next moves to the next line, and to a new page if this one is full.
+func (w *pdfWriter) next(lines float64) {
+	w.y += pdfLineHeight * lines
+	if w.y > pdf.PageHeight-pdfMargin {
+		w.newPage()
+	}
+}
+
+func (w *pdfWriter) row(label, value string, font pdf.Font) {
+	w.rowAt(pdfPriceX-80, label, value, font)
+}
+
+func (w *pdfWriter) rowAt(x float64, label, value string, font pdf.Font) {
+	w.page.Text(x, w.y, pdfFontSize, font, label)
+	w.page.TextRight(pdfTotalX, w.y, pdfFontSize, font, value)
+	w.next(1)
+}
+
+func renderPDF(inv *Invoice, out io.Writer) error {
+	w := &pdfWriter{doc: pdf.New()}
+	w.newPage()
+
+	w.page.Text(pdfMargin, w.y, 22, pdf.Bold, "INVOICE")
+	w.page.TextRight(pdfTotalX, w.y, pdfFontSize, pdf.Bold, "Odin Leather Store")
+	w.next(2)
+
+	w.page.Text(pdfMargin, w.y, pdfFontSize, pdf.Regular, fmt.Sprintf("Order #%d", inv.OrderNumber))
+	w.page.TextRight(pdfTotalX, w.y, pdfFontSize, pdf.Regular, "Ref: "+inv.Ref)
+	w.next(1)
+	w.page.Text(pdfMargin, w.y, pdfFontSize, pdf.Regular, "Issued on: "+inv.IssuanceDate.Format(time.DateOnly))
+	if !inv.DueDate.IsZero() {
+		w.page.TextRight(pdfTotalX, w.y, pdfFontSize, pdf.Regular, "Due on: "+inv.DueDate.Format(time.DateOnly))
+	}
+	w.next(2)
+
+	w.page.Text(pdfMargin, w.y, pdfFontSize, pdf.Bold, "Billed to")
+	w.next(1)
+	w.page.Text(pdfMargin, w.y, pdfFontSize, pdf.Regular, inv.ClientName)
+	w.next(1)
+	for _, line := range [][]string{inv.ClientPhones, inv.ClientEmails, inv.ClientAddress} {
+		if len(line) > 0 {
+			w.page.Text(pdfMargin, w.y, pdfFontSize, pdf.Regular, strings.Join(line, ", "))
+			w.next(1)
+		}
+	}
+	w.next(1)
+
+	w.page.Text(pdfMargin, w.y, pdfFontSize, pdf.Bold, "Item")
+	w.page.TextRight(pdfQtyX, w.y, pdfFontSize, pdf.Bold, "Qty")
+	w.page.TextRight(pdfPriceX, w.y, pdfFontSize, pdf.Bold, "Unit Price")
+	w.page.TextRight(pdfTotalX, w.y, pdfFontSize, pdf.Bold, "Total")
+	w.page.Line(pdfMargin, w.y+5, pdfTotalX, w.y+5, 0.5)
+	w.next(1.5)
+
+	for _, line := range inv.Lines {
+		w.page.Text(pdfMargin, w.y, pdfFontSize, pdf.Regular, line.Description)
+		w.page.TextRight(pdfQtyX, w.y, pdfFontSize, pdf.Regular, strconv.Itoa(int(line.Quantity)))
+		w.page.TextRight(pdfPriceX, w.y, pdfFontSize, pdf.Regular, FormatMoney(line.UnitPrice))
+		w.page.TextRight(pdfTotalX, w.y, pdfFontSize, pdf.Regular, FormatMoney(line.Total))
+		if line.SKU != "" {
+			w.next(1)
+			w.page.Text(pdfMargin, w.y, pdfFontSize-2, pdf.Regular, line.SKU)
+		}
+		w.next(1.25)
+	}
+
+	w.page.Line(pdfMargin, w.y-8, pdfTotalX, w.y-8, 0.5)
+	w.next(0.5)
+
+	w.row("Subtotal", FormatMoney(inv.Subtotal), pdf.Regular)
+	for _, addon := range inv.Addons {
+		w.row(AddonLabel(addon), FormatMoney(addon.Value), pdf.Regular)
+	}
+	w.row("Total", FormatMoney(inv.Total), pdf.Bold)
+	w.next(1)
+
+	if len(inv.Payments) > 0 {
+		w.page.Text(pdfMargin, w.y, pdfFontSize, pdf.Bold, "Payments")
+		w.next(1)
+		for _, payment := range inv.Payments {
+			// The payments' labels are too long for the totals' column.
+			w.rowAt(pdfMargin, payment.Label(), FormatMoney(payment.Amount), pdf.Regular)
+		}
+	}
+	w.row("Paid", FormatMoney(inv.Paid), pdf.Regular)
+	w.row("Remaining", FormatMoney(inv.Remaining), pdf.Bold)
+	if inv.Credit > 0 {
+		w.row("Credit", FormatMoney(inv.Credit), pdf.Bold)
+	}
+
+	_, err := w.doc.WriteTo(out)
+	return err
+}
@@ -0,0 +1,68 @@
+package invoice
+
+import (
+	"time"
+
+	"github.com/omareloui/odinls/internal/application/core/order"
+)
+
+// Receipt is the printable proof of a payment or a refund, with the order's
+// balance right after it.
+type Receipt struct {
+	Number    string
+	OrderID   string
+	PaymentID string
+
+	OrderNumber uint
+	Ref         string
+	ClientName  string
+
+	Date       time.Time
+	Amount     float64
+	IsRefund   bool
+	Method     order.PaymentMethodEnum
+	Reference  string
+	Reason     string
+	ReceivedBy string
+
+	OrderTotal float64
+	PaidSoFar  float64
+	Remaining  float64
+	Credit     float64
+}
+
+func NewReceipt(ord *order.Order, payment *order.Payment) *Receipt {
+	rec := &Receipt{
+		Number:      payment.ReceiptNumber(ord),
+		OrderID:     ord.ID,
+		PaymentID:   payment.ID,
+		OrderNumber: ord.Number,
+		Ref:         ord.RefView(),
+		ClientName:  ord.CustomerName,
+		Date:        payment.Date,
+		Amount:      payment.Amount,
+		IsRefund:    payment.IsRefund(),
+		Method:      payment.Method,
+		Reference:   payment.Reference,
+		Reason:      payment.Reason,
+		ReceivedBy:  payment.ReceivedByName,
+		OrderTotal:  ord.TotalPrice(),
+	}
+	if rec.IsRefund {
+		rec.Amount = -rec.Amount
+	}
+
+	if ord.Client != nil {
+		rec.ClientName = ord.Client.Name
+	}
+
+	for _, p := range ord.Payments {
+		if p.Number <= payment.Number {
+			rec.PaidSoFar += p.Amount
+		}
+	}
+	rec.Remaining = max(rec.OrderTotal-rec.PaidSoFar, 0)
+	rec.Credit = max(rec.PaidSoFar-rec.OrderTotal, 0)
+
+	return rec
+}
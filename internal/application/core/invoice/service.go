@@ -0,0 +1,15 @@
+package invoice
+
+import (
+	"io"
+
+	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+)
+
+type InvoiceService interface {
+	GetOrderInvoice(claims *jwtadapter.AccessClaims, orderID string) (*Invoice, error)
+	GetMyOrderInvoice(claims *jwtadapter.AccessClaims, orderID string) (*Invoice, error)
+	GetPaymentReceipt(claims *jwtadapter.AccessClaims, orderID, paymentID string) (*Receipt, error)
+	GetMyPaymentReceipt(claims *jwtadapter.AccessClaims, orderID, paymentID string) (*Receipt, error)
+	RenderPDF(inv *Invoice, w io.Writer) error
+}
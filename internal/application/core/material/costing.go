@@ -0,0 +1,93 @@
+package material
+
+import (
+	"slices"
+	"time"
+
+	"github.com/omareloui/odinls/internal/application/core/costing"
+)
+
+// Lot is a quantity of the material received at the same unit price, like a
+// batch of hides bought together.
+type Lot struct {
+	Quantity  float64   `json:"quantity" bson:"quantity"`
+	UnitPrice float64   `json:"unit_price" bson:"unit_price"`
+	Date      time.Time `json:"date" bson:"date"`
+}
+
+// UnitCosts is the material's unit cost by each of the costing methods.
+type UnitCosts struct {
+	Material *Material
+
+	LastPrice       float64
+	WeightedAverage float64
+	FIFO            float64
+}
+
+func (c UnitCosts) ByMethod(method costing.MaterialCostingEnum) float64 {
+	switch method {
+	case costing.MaterialCostingWeightedAverage:
+		return c.WeightedAverage
+	case costing.MaterialCostingFIFO:
+		return c.FIFO
+	}
+	return c.LastPrice
+}
+
+// Spread is the difference between the highest and the lowest unit costs.
+func (c UnitCosts) Spread() float64 {
+	return max(c.LastPrice, c.WeightedAverage, c.FIFO) - min(c.LastPrice, c.WeightedAverage, c.FIFO)
+}
+
+func (m *Material) UnitCosts() UnitCosts {
+	return UnitCosts{
+		Material:        m,
+		LastPrice:       m.PricePerUnit,
+		WeightedAverage: m.weightedAverageCost(),
+		FIFO:            m.fifoCost(),
+	}
+}
+
+// UnitCost is the material's unit cost by the given costing method, it's the
+// last price if the material has no lots.
+func (m *Material) UnitCost(method costing.MaterialCostingEnum) float64 {
+	return m.UnitCosts().ByMethod(method)
+}
+
+// weightedAverageCost is the average unit price of all the received lots,
+// weighted by their quantities.
+func (m *Material) weightedAverageCost() float64 {
+	var quantity, total float64
+	for _, lot := range m.Lots {
+		quantity += lot.Quantity
+		total += lot.Quantity * lot.UnitPrice
+	}
+	if quantity <= 0 {
+		return m.PricePerUnit
+	}
+	return total / quantity
+}
+
+// fifoCost is the unit price of the oldest lot that still has stock on hand,
+// taking out of the stock is assumed to use the oldest lots first.
+func (m *Material) fifoCost() float64 {
+	lots := slices.Clone(m.Lots)
+	slices.SortStableFunc(lots, func(a, b Lot) int {
+		return a.Date.Compare(b.Date)
+	})
+
+	var received float64
+	for _, lot := range lots {
+		received += lot.Quantity
+	}
+
+	used := received - m.QuantityOnHand
+	for _, lot := range lots {
+		if used < lot.Quantity {
+			return lot.UnitPrice
+		}
+		used -= lot.Quantity
+	}
+
+	return m.PricePerUnit
+}
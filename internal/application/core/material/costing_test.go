@@ -0,0 +1,47 @@
+package material
+
+import (
+	"testing"
+	"time"
+
+	"github.com/omareloui/odinls/internal/application/core/costing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitCost(t *testing.T) {
+	day := func(month time.Month) time.Time {
+		return time.Date(2024, month, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	// Out of their dates' order to check the oldest lot is used first.
+	lots := []Lot{
+		{Quantity: 10, UnitPrice: 12, Date: day(time.February)},
+		{Quantity: 10, UnitPrice: 10, Date: day(time.January)},
+		{Quantity: 10, UnitPrice: 15, Date: day(time.March)},
+	}
+
+	tests := []struct {
+		name     string
+		lots     []Lot
+		onHand   float64
+		method   costing.MaterialCostingEnum
+		wantCost float64
+	}{
+		{"last price", lots, 30, costing.MaterialCostingLastPrice, 20},
+		{"weighted average", lots, 5, costing.MaterialCostingWeightedAverage, 37.0 / 3},
+		{"weighted average without lots", nil, 5, costing.MaterialCostingWeightedAverage, 20},
+		{"fifo with nothing used", lots, 30, costing.MaterialCostingFIFO, 10},
+		{"fifo with the oldest lot used up", lots, 20, costing.MaterialCostingFIFO, 12},
+		{"fifo with part of a lot used", lots, 15, costing.MaterialCostingFIFO, 12},
+		{"fifo with only the newest lot left", lots, 5, costing.MaterialCostingFIFO, 15},
+		{"fifo with all the lots used", lots, 0, costing.MaterialCostingFIFO, 20},
+		{"fifo without lots", nil, 5, costing.MaterialCostingFIFO, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Material{PricePerUnit: 20, QuantityOnHand: tt.onHand, Lots: tt.lots}
+			assert.InDelta(t, tt.wantCost, m.UnitCost(tt.method), 1e-9)
+		})
+	}
+}
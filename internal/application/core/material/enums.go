@@ -8,11 +8,16 @@ type (
 const (
 	UnitUnknown Unit = "Unknown"
 	UnitMl      Unit = "ml"
+	UnitL       Unit = "l"
 	UnitFt2     Unit = "ft²"
+	UnitM2      Unit = "m²"
+	UnitDm2     Unit = "dm²"
 	UnitM       Unit = "m"
 	UnitCm      Unit = "cm"
 	UnitPiece   Unit = "piece"
+	UnitSpool   Unit = "spool"
 	UnitGram    Unit = "g"
+	UnitKg      Unit = "kg"
 )
 
 const (
@@ -57,3 +62,25 @@ func MaterialsCategoriesEnums() []CategoryEnum {
 		CategoryEmbellishment, CategoryConsumable,
 	}
 }
+
+// HideGradeEnum is the grade of a leather hide, by how much of its surface
+// is usable without scars or defects.
+type HideGradeEnum string
+
+const (
+	HideGradeA HideGradeEnum = "A"
+	HideGradeB HideGradeEnum = "B"
+	HideGradeC HideGradeEnum = "C"
+	HideGradeD HideGradeEnum = "D"
+)
+
+func (g HideGradeEnum) View() string {
+	if g == "" {
+		return "Ungraded"
+	}
+	return "Grade " + string(g)
+}
+
+func HideGradesEnums() []HideGradeEnum {
+	return []HideGradeEnum{HideGradeA, HideGradeB, HideGradeC, HideGradeD}
+}
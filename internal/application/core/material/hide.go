@@ -0,0 +1,52 @@
+package material
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/omareloui/odinls/internal/errs"
+)
+
+var ErrNotHideMaterial = fmt.Errorf("%w: only leather in %s is tracked by hides", errs.ErrInvalidEnum, UnitFt2)
+
+// Hide is a single leather hide, or a scrap left of one, of a material. The
+// material's quantity on hand still moves through the stock ledger, hides
+// track which piece of leather that quantity is in.
+type Hide struct {
+	ID string `json:"id" bson:"_id,omitempty" formfield:"-"`
+
+	MaterialID   string `json:"material_id" bson:"material" formfield:"-" validate:"required,mongodb"`
+	MaterialName string `json:"material_name" bson:"material_name" formfield:"-"`
+
+	// ParentID is the hide the scrap was cut from, it's empty for whole hides.
+	ParentID string `json:"parent_id" bson:"parent,omitempty" formfield:"-"`
+
+	Label     string        `json:"label" bson:"label" formfield:"label" conform:"trim" validate:"required,max=255"`
+	Tannery   string        `json:"tannery" bson:"tannery,omitempty" formfield:"tannery" conform:"trim,title" validate:"max=255"`
+	Grade     HideGradeEnum `json:"grade" bson:"grade,omitempty" formfield:"grade" conform:"trim,upper"`
+	Thickness float64       `json:"thickness" bson:"thickness,omitempty" formfield:"thickness" validate:"min=0"`
+
+	// Area is the measured area in ft², the remaining area is what's left of
+	// it after the consumptions.
+	Area          float64 `json:"area" bson:"area" formfield:"area" validate:"required,gt=0"`
+	RemainingArea float64 `json:"remaining_area" bson:"remaining_area" formfield:"-"`
+
+	PhotoURL string `json:"photo_url" bson:"photo_url,omitempty" formfield:"photo_url" conform:"trim" validate:"omitempty,url"`
+	Note     string `json:"note" bson:"note,omitempty" formfield:"note" conform:"trim"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at" formfield:"-"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at" formfield:"-"`
+}
+
+func (h *Hide) IsScrap() bool {
+	return h.ParentID != ""
+}
+
+func (h *Hide) IsUsedUp() bool {
+	return h.RemainingArea <= 0
+}
+
+// TracksHides is whether the material is leather bought by the hide.
+func (m *Material) TracksHides() bool {
+	return m.Category == CategoryLeather && m.Unit == UnitFt2
+}
@@ -0,0 +1,124 @@
+package material
+
+import (
+	"errors"
+	"slices"
+
+	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+	"github.com/omareloui/odinls/internal/errs"
+)
+
+// GetHides gets the material's hides, or all the hides if the material ID is
+// empty.
+func (s *materialService) GetHides(claims *jwtadapter.AccessClaims, materialID string) ([]Hide, error) {
+	if claims == nil || (!claims.Role.IsModerator() && !claims.IsCraftsman()) {
+		return nil, errs.ErrForbidden
+	}
+
+	return s.repo.GetHides(materialID)
+}
+
+func (s *materialService) GetHideByID(claims *jwtadapter.AccessClaims, id string) (*Hide, error) {
+	if claims == nil || (!claims.Role.IsModerator() && !claims.IsCraftsman()) {
+		return nil, errs.ErrForbidden
+	}
+
+	return s.repo.GetHideByID(id)
+}
+
+func (s *materialService) CreateHide(claims *jwtadapter.AccessClaims, materialID string, hide *Hide) (*Hide, error) {
+	if claims == nil || !claims.Role.IsModerator() {
+		return nil, errs.ErrForbidden
+	}
+
+	mat, err := s.repo.GetMaterialByID(materialID)
+	if err != nil {
+		return nil, err
+	}
+	if !mat.TracksHides() {
+		return nil, ErrNotHideMaterial
+	}
+
+	hide.MaterialID = mat.ID
+	hide.MaterialName = mat.Name
+	hide.ParentID = ""
+
+	err = s.sanitizer.SanitizeStruct(hide)
+	if err != nil {
+		return nil, errs.ErrSanitizer
+	}
+
+	if err := s.validator.Validate(hide); err != nil {
+		return nil, err
+	}
+
+	if hide.Grade != "" && !slices.Contains(HideGradesEnums(), hide.Grade) {
+		return nil, errs.ErrInvalidEnum
+	}
+
+	hide.RemainingArea = hide.Area
+
+	return s.repo.CreateHide(hide)
+}
+
+// ConsumeHide takes the area out of what's remaining of the hide, a negative
+// area puts it back.
+func (s *materialService) ConsumeHide(claims *jwtadapter.AccessClaims, id string, area float64) (*Hide, error) {
+	if claims == nil || (!claims.Role.IsModerator() && !claims.IsCraftsman()) {
+		return nil, errs.ErrForbidden
+	}
+
+	return s.repo.ConsumeHideArea(id, area)
+}
+
+// RecordScrap records the usable scrap left of a hide as a hide of its own,
+// what's left of the hide other than the scrap is the returned waste. The
+// hide has to be of the given material.
+func (s *materialService) RecordScrap(claims *jwtadapter.AccessClaims, materialID, hideID string, area float64) (*Hide, float64, error) {
+	if claims == nil || (!claims.Role.IsModerator() && !claims.IsCraftsman()) {
+		return nil, 0, errs.ErrForbidden
+	}
+
+	hide, err := s.repo.GetHideByID(hideID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if hide.MaterialID != materialID {
+		return nil, 0, errs.ErrDocumentNotFound
+	}
+
+	if area <= 0 {
+		return nil, 0, errs.ErrInvalidFloat
+	}
+	if area > hide.RemainingArea {
+		return nil, 0, errs.ErrInsufficientStock
+	}
+
+	// The hide is used up first, so a concurrent cut from it fails instead of
+	// the area being counted in both.
+	if _, err := s.repo.ConsumeHideArea(hide.ID, hide.RemainingArea); err != nil {
+		return nil, 0, err
+	}
+
+	scrap := &Hide{
+		MaterialID:    hide.MaterialID,
+		MaterialName:  hide.MaterialName,
+		ParentID:      hide.ID,
+		Label:         hide.Label + " (Scrap)",
+		Tannery:       hide.Tannery,
+		Grade:         hide.Grade,
+		Thickness:     hide.Thickness,
+		Area:          area,
+		RemainingArea: area,
+	}
+
+	created, err := s.repo.CreateHide(scrap)
+	if err != nil {
+		if _, rerr := s.repo.ConsumeHideArea(hide.ID, -hide.RemainingArea); rerr != nil {
+			return nil, 0, errors.Join(err, rerr)
+		}
+		return nil, 0, err
+	}
+
+	return created, hide.RemainingArea - area, nil
+}
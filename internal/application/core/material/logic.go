@@ -1,15 +1,38 @@
 package material
 
 import (
+	"time"
+
 	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
 	"github.com/omareloui/odinls/internal/errs"
 	"github.com/omareloui/odinls/internal/interfaces"
 )
 
+// Hook is called after something happens to a material, like getting created
+// or having its price changed.
+type Hook func(claims *jwtadapter.AccessClaims, mat *Material) error
+
+// PriceChangeError is a price change hook failing after the material got
+// saved with its new price, it's reported apart so the save isn't retried.
+type PriceChangeError struct {
+	Err error
+}
+
+func (e *PriceChangeError) Error() string {
+	return "running the material's price change hooks: " + e.Err.Error()
+}
+
+func (e *PriceChangeError) Unwrap() error {
+	return e.Err
+}
+
 type materialService struct {
 	repo      MaterialRepository
 	validator interfaces.Validator
 	sanitizer interfaces.Sanitizer
+
+	createHooks      []Hook
+	priceChangeHooks []Hook
 }
 
 func NewMaterialService(repo MaterialRepository, validator interfaces.Validator, sanitizer interfaces.Sanitizer) *materialService {
@@ -20,6 +43,36 @@ func NewMaterialService(repo MaterialRepository, validator interfaces.Validator,
 	}
 }
 
+// OnCreate registers a hook to run after a material is created, it's how the
+// opening stock gets recorded.
+func (s *materialService) OnCreate(hook Hook) {
+	s.createHooks = append(s.createHooks, hook)
+}
+
+// OnPriceChange registers a hook to run after a material's price changes,
+// it's how the products using the material get repriced.
+func (s *materialService) OnPriceChange(hook Hook) {
+	s.priceChangeHooks = append(s.priceChangeHooks, hook)
+}
+
+func (s *materialService) runHooks(hooks []Hook, claims *jwtadapter.AccessClaims, mat *Material) error {
+	for _, hook := range hooks {
+		if err := hook(claims, mat); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// afterPriceChange runs the price change hooks on the saved material, their
+// error is returned as a PriceChangeError with the material.
+func (s *materialService) afterPriceChange(claims *jwtadapter.AccessClaims, mat *Material) (*Material, error) {
+	if err := s.runHooks(s.priceChangeHooks, claims, mat); err != nil {
+		return mat, &PriceChangeError{Err: err}
+	}
+	return mat, nil
+}
+
 func (s *materialService) GetMaterials(claims *jwtadapter.AccessClaims, options ...RetrieveOptsFunc) ([]Material, error) {
 	if claims == nil || !claims.Role.IsModerator() {
 		return nil, errs.ErrForbidden
@@ -50,9 +103,25 @@ func (s *materialService) CreateMaterial(claims *jwtadapter.AccessClaims, mat *M
 		return nil, err
 	}
 
-	return s.repo.CreateMaterial(mat, options...)
+	now := time.Now()
+	mat.LastPriceUpdate = now
+	mat.PriceHistory = []PriceEntry{{PricePerUnit: mat.PricePerUnit, Date: now}}
+	mat.Lots = nil
+	if mat.QuantityOnHand > 0 {
+		mat.Lots = []Lot{{Quantity: mat.QuantityOnHand, UnitPrice: mat.PricePerUnit, Date: now}}
+	}
+
+	created, err := s.repo.CreateMaterial(mat, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, s.runHooks(s.createHooks, claims, created)
 }
 
+// UpdateMaterialByID updates the material from its form. If repricing after
+// the price change fails the material stays updated, and the error is a
+// PriceChangeError.
 func (s *materialService) UpdateMaterialByID(claims *jwtadapter.AccessClaims, id string, umat *Material, options ...RetrieveOptsFunc) (*Material, error) {
 	if claims == nil || !claims.Role.IsAdmin() {
 		return nil, errs.ErrForbidden
@@ -67,5 +136,87 @@ func (s *materialService) UpdateMaterialByID(claims *jwtadapter.AccessClaims, id
 		return nil, err
 	}
 
-	return s.repo.UpdateMaterialByID(id, umat, options...)
+	mat, err := s.repo.GetMaterialByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	priceChanged := mat.PricePerUnit != umat.PricePerUnit
+
+	// The quantity on hand and reserved only change through the stock
+	// movements and reservations.
+	umat.QuantityOnHand = mat.QuantityOnHand
+	umat.QuantityReserved = mat.QuantityReserved
+	umat.LastPriceUpdate = mat.LastPriceUpdate
+	umat.PriceHistory = mat.PriceHistory
+	umat.Lots = mat.Lots
+	if priceChanged {
+		now := time.Now()
+		umat.LastPriceUpdate = now
+		umat.PriceHistory = append(umat.PriceHistory, PriceEntry{PricePerUnit: umat.PricePerUnit, Date: now})
+	}
+
+	updated, err := s.repo.UpdateMaterialByID(id, umat, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if priceChanged {
+		return s.afterPriceChange(claims, updated)
+	}
+
+	return updated, nil
+}
+
+// ReceiveLot records a received lot of the material and moves its price to
+// the lot's unit price. The received quantity is added to the stock by its
+// movement, not here. If repricing after the price change fails the lot stays
+// received, and the error is a PriceChangeError.
+func (s *materialService) ReceiveLot(claims *jwtadapter.AccessClaims, id string, lot Lot) (*Material, error) {
+	if claims == nil || !claims.Role.IsAdmin() {
+		return nil, errs.ErrForbidden
+	}
+
+	mat, err := s.repo.GetMaterialByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if lot.Date.IsZero() {
+		lot.Date = time.Now()
+	}
+
+	var price *PriceEntry
+	priceChanged := mat.PricePerUnit != lot.UnitPrice
+	if priceChanged {
+		price = &PriceEntry{PricePerUnit: lot.UnitPrice, Date: lot.Date}
+	}
+
+	updated, err := s.repo.AddMaterialLot(id, lot, price)
+	if err != nil {
+		return nil, err
+	}
+
+	if priceChanged {
+		return s.afterPriceChange(claims, updated)
+	}
+
+	return updated, nil
+}
+
+func (s *materialService) GetUnitCosts(claims *jwtadapter.AccessClaims) ([]UnitCosts, error) {
+	if claims == nil || !claims.Role.IsModerator() {
+		return nil, errs.ErrForbidden
+	}
+
+	mats, err := s.repo.GetMaterials()
+	if err != nil {
+		return nil, err
+	}
+
+	costs := make([]UnitCosts, len(mats))
+	for i := range mats {
+		costs[i] = mats[i].UnitCosts()
+	}
+	return costs, nil
 }
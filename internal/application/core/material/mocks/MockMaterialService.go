@@ -14,8 +14,68 @@ type MockMaterialService struct {
 	mock.Mock
 }
 
+// ConsumeHide provides a mock function with given fields: claims, id, area
+func (_m *MockMaterialService) ConsumeHide(claims *jwtadapter.AccessClaims, id string, area float64) (*material.Hide, error) {
+	ret := _m.Called(claims, id, area)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeHide")
+	}
+
+	var r0 *material.Hide
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*jwtadapter.AccessClaims, string, float64) (*material.Hide, error)); ok {
+		return rf(claims, id, area)
+	}
+	if rf, ok := ret.Get(0).(func(*jwtadapter.AccessClaims, string, float64) *material.Hide); ok {
+		r0 = rf(claims, id, area)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*material.Hide)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*jwtadapter.AccessClaims, string, float64) error); ok {
+		r1 = rf(claims, id, area)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateHide provides a mock function with given fields: claims, materialID, hide
+func (_m *MockMaterialService) CreateHide(claims *jwtadapter.AccessClaims, materialID string, hide *material.Hide) (*material.Hide, error) {
+	ret := _m.Called(claims, materialID, hide)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateHide")
+	}
+
+	var r0 *material.Hide
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*jwtadapter.AccessClaims, string, *material.Hide) (*material.Hide, error)); ok {
+		return rf(claims, materialID, hide)
+	}
+	if rf, ok := ret.Get(0).(func(*jwtadapter.AccessClaims, string, *material.Hide) *material.Hide); ok {
+		r0 = rf(claims, materialID, hide)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*material.Hide)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*jwtadapter.AccessClaims, string, *material.Hide) error); ok {
+		r1 = rf(claims, materialID, hide)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateMaterial provides a mock function with given fields: claims, mat, opts
-func (_m *MockMaterialService) CreateMaterial(claims *jwtadapter.AccessClaims, mat *material.Material, opts ...material.RetrieveOptsFunc) error {
+func (_m *MockMaterialService) CreateMaterial(claims *jwtadapter.AccessClaims, mat *material.Material, opts ...material.RetrieveOptsFunc) (*material.Material, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -29,14 +89,86 @@ func (_m *MockMaterialService) CreateMaterial(claims *jwtadapter.AccessClaims, m
 		panic("no return value specified for CreateMaterial")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*jwtadapter.AccessClaims, *material.Material, ...material.RetrieveOptsFunc) error); ok {
+	var r0 *material.Material
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*jwtadapter.AccessClaims, *material.Material, ...material.RetrieveOptsFunc) (*material.Material, error)); ok {
+		return rf(claims, mat, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(*jwtadapter.AccessClaims, *material.Material, ...material.RetrieveOptsFunc) *material.Material); ok {
 		r0 = rf(claims, mat, opts...)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*material.Material)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*jwtadapter.AccessClaims, *material.Material, ...material.RetrieveOptsFunc) error); ok {
+		r1 = rf(claims, mat, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHideByID provides a mock function with given fields: claims, id
+func (_m *MockMaterialService) GetHideByID(claims *jwtadapter.AccessClaims, id string) (*material.Hide, error) {
+	ret := _m.Called(claims, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHideByID")
+	}
+
+	var r0 *material.Hide
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*jwtadapter.AccessClaims, string) (*material.Hide, error)); ok {
+		return rf(claims, id)
+	}
+	if rf, ok := ret.Get(0).(func(*jwtadapter.AccessClaims, string) *material.Hide); ok {
+		r0 = rf(claims, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*material.Hide)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*jwtadapter.AccessClaims, string) error); ok {
+		r1 = rf(claims, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHides provides a mock function with given fields: claims, materialID
+func (_m *MockMaterialService) GetHides(claims *jwtadapter.AccessClaims, materialID string) ([]material.Hide, error) {
+	ret := _m.Called(claims, materialID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHides")
+	}
+
+	var r0 []material.Hide
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*jwtadapter.AccessClaims, string) ([]material.Hide, error)); ok {
+		return rf(claims, materialID)
+	}
+	if rf, ok := ret.Get(0).(func(*jwtadapter.AccessClaims, string) []material.Hide); ok {
+		r0 = rf(claims, materialID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]material.Hide)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*jwtadapter.AccessClaims, string) error); ok {
+		r1 = rf(claims, materialID)
+	} else {
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
 // GetMaterialByID provides a mock function with given fields: claims, id, opts
@@ -113,8 +245,105 @@ func (_m *MockMaterialService) GetMaterials(claims *jwtadapter.AccessClaims, opt
 	return r0, r1
 }
 
+// GetUnitCosts provides a mock function with given fields: claims
+func (_m *MockMaterialService) GetUnitCosts(claims *jwtadapter.AccessClaims) ([]material.UnitCosts, error) {
+	ret := _m.Called(claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnitCosts")
+	}
+
+	var r0 []material.UnitCosts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*jwtadapter.AccessClaims) ([]material.UnitCosts, error)); ok {
+		return rf(claims)
+	}
+	if rf, ok := ret.Get(0).(func(*jwtadapter.AccessClaims) []material.UnitCosts); ok {
+		r0 = rf(claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]material.UnitCosts)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*jwtadapter.AccessClaims) error); ok {
+		r1 = rf(claims)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceiveLot provides a mock function with given fields: claims, id, lot
+func (_m *MockMaterialService) ReceiveLot(claims *jwtadapter.AccessClaims, id string, lot material.Lot) (*material.Material, error) {
+	ret := _m.Called(claims, id, lot)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReceiveLot")
+	}
+
+	var r0 *material.Material
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*jwtadapter.AccessClaims, string, material.Lot) (*material.Material, error)); ok {
+		return rf(claims, id, lot)
+	}
+	if rf, ok := ret.Get(0).(func(*jwtadapter.AccessClaims, string, material.Lot) *material.Material); ok {
+		r0 = rf(claims, id, lot)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*material.Material)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*jwtadapter.AccessClaims, string, material.Lot) error); ok {
+		r1 = rf(claims, id, lot)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordScrap provides a mock function with given fields: claims, materialID, hideID, area
+func (_m *MockMaterialService) RecordScrap(claims *jwtadapter.AccessClaims, materialID string, hideID string, area float64) (*material.Hide, float64, error) {
+	ret := _m.Called(claims, materialID, hideID, area)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordScrap")
+	}
+
+	var r0 *material.Hide
+	var r1 float64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*jwtadapter.AccessClaims, string, string, float64) (*material.Hide, float64, error)); ok {
+		return rf(claims, materialID, hideID, area)
+	}
+	if rf, ok := ret.Get(0).(func(*jwtadapter.AccessClaims, string, string, float64) *material.Hide); ok {
+		r0 = rf(claims, materialID, hideID, area)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*material.Hide)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*jwtadapter.AccessClaims, string, string, float64) float64); ok {
+		r1 = rf(claims, materialID, hideID, area)
+	} else {
+		r1 = ret.Get(1).(float64)
+	}
+
+	if rf, ok := ret.Get(2).(func(*jwtadapter.AccessClaims, string, string, float64) error); ok {
+		r2 = rf(claims, materialID, hideID, area)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // UpdateMaterialByID provides a mock function with given fields: claims, id, mat, opts
-func (_m *MockMaterialService) UpdateMaterialByID(claims *jwtadapter.AccessClaims, id string, mat *material.Material, opts ...material.RetrieveOptsFunc) error {
+func (_m *MockMaterialService) UpdateMaterialByID(claims *jwtadapter.AccessClaims, id string, mat *material.Material, opts ...material.RetrieveOptsFunc) (*material.Material, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -128,14 +357,26 @@ func (_m *MockMaterialService) UpdateMaterialByID(claims *jwtadapter.AccessClaim
 		panic("no return value specified for UpdateMaterialByID")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*jwtadapter.AccessClaims, string, *material.Material, ...material.RetrieveOptsFunc) error); ok {
+	var r0 *material.Material
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*jwtadapter.AccessClaims, string, *material.Material, ...material.RetrieveOptsFunc) (*material.Material, error)); ok {
+		return rf(claims, id, mat, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(*jwtadapter.AccessClaims, string, *material.Material, ...material.RetrieveOptsFunc) *material.Material); ok {
 		r0 = rf(claims, id, mat, opts...)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*material.Material)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(*jwtadapter.AccessClaims, string, *material.Material, ...material.RetrieveOptsFunc) error); ok {
+		r1 = rf(claims, id, mat, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NewMockMaterialService creates a new instance of MockMaterialService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
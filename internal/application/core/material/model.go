@@ -18,18 +18,36 @@ type Material struct {
 	Unit         Unit    `json:"unit" bson:"unit" conform:"trim,lower" formfield:"unit" validate:"required"`
 	PricePerUnit float64 `json:"price_per_unit" bson:"price_per_unit" formfield:"price_per_unit" validate:"required,min=0"`
 
-	QuantityOnHand  float64 `json:"quantity_on_hand" bson:"quantity_on_hand" formfield:"quantity_on_hand" validate:"min=0"`
-	ReorderLevel    float64 `json:"reorder_level" bson:"reorder_level" formfield:"reorder_level" validate:"min=0"`
-	ReorderQuantity float64 `json:"reorder_quantity" bson:"reorder_quantity" formfield:"reorder_quantity" validate:"min=0"`
+	// LengthPerPiece is the length in meters of a piece or a spool of the
+	// material, like a thread's spool, to convert between them.
+	LengthPerPiece float64 `json:"length_per_piece" bson:"length_per_piece,omitempty" formfield:"length_per_piece" validate:"min=0"`
+
+	QuantityOnHand   float64 `json:"quantity_on_hand" bson:"quantity_on_hand" formfield:"quantity_on_hand" validate:"min=0"`
+	QuantityReserved float64 `json:"quantity_reserved" bson:"quantity_reserved" formfield:"-"`
+	ReorderLevel     float64 `json:"reorder_level" bson:"reorder_level" formfield:"reorder_level" validate:"min=0"`
+	ReorderQuantity  float64 `json:"reorder_quantity" bson:"reorder_quantity" formfield:"reorder_quantity" validate:"min=0"`
 
 	Tags []string `json:"tags" bson:"tags,omitempty" formfield:"tags"`
 
 	SupplierID string `json:"supplier_id" bson:"supplier" formfield:"supplier_id" validate:"required,mongodb"`
 
-	LastPriceUpdate time.Time `json:"last_price_update" bson:"last_price_update" formfield:"last_price_update"`
+	LastPriceUpdate time.Time    `json:"last_price_update" bson:"last_price_update" formfield:"last_price_update"`
+	PriceHistory    []PriceEntry `json:"price_history" bson:"price_history,omitempty" formfield:"-"`
+	Lots            []Lot        `json:"lots" bson:"lots,omitempty" formfield:"-"`
 
 	CreatedAt time.Time `json:"created_at" bson:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
 
 	Supplier *supplier.Supplier `json:"supplier" bson:"populated_supplier,omitempty" formfield:"-"`
 }
+
+// Available is the quantity on hand that isn't reserved for confirmed orders,
+// it can go negative if an order got confirmed without enough stock.
+func (m *Material) Available() float64 {
+	return m.QuantityOnHand - m.QuantityReserved
+}
+
+type PriceEntry struct {
+	PricePerUnit float64   `json:"price_per_unit" bson:"price_per_unit"`
+	Date         time.Time `json:"date" bson:"date"`
+}
@@ -5,4 +5,14 @@ type MaterialRepository interface {
 	GetMaterialByID(id string, opts ...RetrieveOptsFunc) (*Material, error)
 	CreateMaterial(mat *Material, opts ...RetrieveOptsFunc) (*Material, error)
 	UpdateMaterialByID(id string, mat *Material, opts ...RetrieveOptsFunc) (*Material, error)
+	// AddMaterialLot adds the lot to the material, and moves its price to the
+	// price entry if it's set. It doesn't touch the material's quantities.
+	AddMaterialLot(id string, lot Lot, price *PriceEntry) (*Material, error)
+
+	GetHides(materialID string) ([]Hide, error)
+	GetHideByID(id string) (*Hide, error)
+	CreateHide(hide *Hide) (*Hide, error)
+	// ConsumeHideArea takes the area out of the hide's remaining area, it
+	// fails with errs.ErrInsufficientStock if there isn't enough of it left.
+	ConsumeHideArea(id string, area float64) (*Hide, error)
 }
@@ -7,4 +7,12 @@ type MaterialService interface {
 	GetMaterialByID(claims *jwtadapter.AccessClaims, id string, opts ...RetrieveOptsFunc) (*Material, error)
 	CreateMaterial(claims *jwtadapter.AccessClaims, mat *Material, opts ...RetrieveOptsFunc) (*Material, error)
 	UpdateMaterialByID(claims *jwtadapter.AccessClaims, id string, mat *Material, opts ...RetrieveOptsFunc) (*Material, error)
+	ReceiveLot(claims *jwtadapter.AccessClaims, id string, lot Lot) (*Material, error)
+	GetUnitCosts(claims *jwtadapter.AccessClaims) ([]UnitCosts, error)
+
+	GetHides(claims *jwtadapter.AccessClaims, materialID string) ([]Hide, error)
+	GetHideByID(claims *jwtadapter.AccessClaims, id string) (*Hide, error)
+	CreateHide(claims *jwtadapter.AccessClaims, materialID string, hide *Hide) (*Hide, error)
+	ConsumeHide(claims *jwtadapter.AccessClaims, id string, area float64) (*Hide, error)
+	RecordScrap(claims *jwtadapter.AccessClaims, materialID, hideID string, area float64) (*Hide, float64, error)
 }
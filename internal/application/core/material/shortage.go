@@ -0,0 +1,39 @@
+package material
+
+// Shortage is how much of a material is missing for what's planned, like an
+// order or a list of variants to make.
+type Shortage struct {
+	MaterialID   string
+	MaterialName string
+	Unit         Unit
+
+	Required  float64
+	Available float64
+}
+
+func (s Shortage) Missing() float64 {
+	return s.Required - s.Available
+}
+
+// FindShortages compares the required quantities of the materials, in the
+// order of ids, with what's available of them. A material that isn't in
+// materials, like a deleted one, has nothing available.
+func FindShortages(ids []string, required map[string]float64, materials map[string]*Material) []Shortage {
+	shortages := []Shortage{}
+	for _, id := range ids {
+		mat, ok := materials[id]
+		if !ok {
+			mat = &Material{ID: id, Name: id}
+		}
+		if available := mat.Available(); available < required[id] {
+			shortages = append(shortages, Shortage{
+				MaterialID:   mat.ID,
+				MaterialName: mat.Name,
+				Unit:         mat.Unit,
+				Required:     required[id],
+				Available:    available,
+			})
+		}
+	}
+	return shortages
+}
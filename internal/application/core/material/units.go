@@ -0,0 +1,113 @@
+package material
+
+import (
+	"fmt"
+
+	"github.com/omareloui/odinls/internal/errs"
+)
+
+type dimension uint8
+
+const (
+	dimensionVolume dimension = iota + 1
+	dimensionArea
+	dimensionLength
+	dimensionCount
+	dimensionMass
+)
+
+// unitFactors are how many of the dimension's base unit are in each unit,
+// the base units are ml, ft², m, piece, and g.
+var unitFactors = map[Unit]struct {
+	dimension dimension
+	factor    float64
+}{
+	UnitMl:    {dimensionVolume, 1},
+	UnitL:     {dimensionVolume, 1000},
+	UnitFt2:   {dimensionArea, 1},
+	UnitM2:    {dimensionArea, 10.7639104},
+	UnitDm2:   {dimensionArea, 0.107639104},
+	UnitM:     {dimensionLength, 1},
+	UnitCm:    {dimensionLength, 0.01},
+	UnitPiece: {dimensionCount, 1},
+	UnitSpool: {dimensionCount, 1},
+	UnitGram:  {dimensionMass, 1},
+	UnitKg:    {dimensionMass, 1000},
+}
+
+type UnitConversionError struct {
+	From Unit
+	To   Unit
+}
+
+func (e *UnitConversionError) Error() string {
+	return fmt.Sprintf("can't convert %s to %s", e.From, e.To)
+}
+
+func (e *UnitConversionError) Unwrap() error {
+	return errs.ErrIncompatibleUnits
+}
+
+// ConvertUnit converts the quantity between two units of the same dimension.
+func ConvertUnit(quantity float64, from, to Unit) (float64, error) {
+	if from == to {
+		return quantity, nil
+	}
+
+	f, ok := unitFactors[from]
+	t, ok2 := unitFactors[to]
+	if !ok || !ok2 || f.dimension != t.dimension {
+		return 0, &UnitConversionError{From: from, To: to}
+	}
+
+	return quantity * f.factor / t.factor, nil
+}
+
+// Normalize converts a quantity of the material in the given unit to the
+// material's unit, an empty unit is the material's. Pieces and lengths are
+// converted with the material's length per piece if it has one.
+func (m *Material) Normalize(quantity float64, from Unit) (float64, error) {
+	if from == "" {
+		return quantity, nil
+	}
+
+	q, err := ConvertUnit(quantity, from, m.Unit)
+	if err == nil || m.LengthPerPiece <= 0 {
+		return q, err
+	}
+
+	f, t := unitFactors[from], unitFactors[m.Unit]
+	switch {
+	case f.dimension == dimensionCount && t.dimension == dimensionLength:
+		return ConvertUnit(quantity*f.factor*m.LengthPerPiece, UnitM, m.Unit)
+	case f.dimension == dimensionLength && t.dimension == dimensionCount:
+		meters, _ := ConvertUnit(quantity, from, UnitM)
+		return meters / m.LengthPerPiece / t.factor, nil
+	}
+
+	return 0, err
+}
+
+// CompatibleUnits are the units a quantity of the material can be entered in.
+func (m *Material) CompatibleUnits() []Unit {
+	units := []Unit{m.Unit}
+	for _, u := range Units() {
+		if u == m.Unit {
+			continue
+		}
+		if _, err := m.Normalize(1, u); err == nil {
+			units = append(units, u)
+		}
+	}
+	return units
+}
+
+func Units() []Unit {
+	return []Unit{
+		UnitMl, UnitL,
+		UnitFt2, UnitM2, UnitDm2,
+		UnitM, UnitCm,
+		UnitPiece, UnitSpool,
+		UnitGram, UnitKg,
+	}
+}
@@ -0,0 +1,83 @@
+package material
+
+import (
+	"testing"
+
+	"github.com/omareloui/odinls/internal/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertUnit(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantity float64
+		from     Unit
+		to       Unit
+		want     float64
+		wantErr  bool
+	}{
+		{"same unit", 3, UnitFt2, UnitFt2, 3, false},
+		{"liters to milliliters", 1.5, UnitL, UnitMl, 1500, false},
+		{"square meters to square feet", 1, UnitM2, UnitFt2, 10.7639104, false},
+		{"square decimeters to square meters", 100, UnitDm2, UnitM2, 1, false},
+		{"centimeters to meters", 250, UnitCm, UnitM, 2.5, false},
+		{"grams to kilograms", 500, UnitGram, UnitKg, 0.5, false},
+		{"spools are counted as pieces", 2, UnitSpool, UnitPiece, 2, false},
+		{"area to length", 1, UnitFt2, UnitM, 0, true},
+		{"unknown unit", 1, UnitUnknown, UnitM, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertUnit(tt.quantity, tt.from, tt.to)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errs.ErrIncompatibleUnits)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	thread := Material{Unit: UnitM, LengthPerPiece: 50}
+	spools := Material{Unit: UnitSpool, LengthPerPiece: 50}
+	leather := Material{Unit: UnitFt2}
+
+	tests := []struct {
+		name     string
+		material Material
+		quantity float64
+		from     Unit
+		want     float64
+		wantErr  bool
+	}{
+		{"empty unit is the material's", leather, 4, "", 4, false},
+		{"same dimension", leather, 1, UnitM2, 10.7639104, false},
+		{"pieces to meters by the length per piece", thread, 2, UnitSpool, 100, false},
+		{"centimeters to spools by the length per piece", spools, 2500, UnitCm, 0.5, false},
+		{"pieces to meters without a length per piece", Material{Unit: UnitM}, 2, UnitPiece, 0, true},
+		{"incompatible dimension", leather, 1, UnitKg, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.material.Normalize(tt.quantity, tt.from)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errs.ErrIncompatibleUnits)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+}
+
+func TestCompatibleUnits(t *testing.T) {
+	thread := Material{Unit: UnitM, LengthPerPiece: 50}
+	assert.Equal(t, []Unit{UnitM, UnitCm, UnitPiece, UnitSpool}, thread.CompatibleUnits())
+
+	leather := Material{Unit: UnitFt2}
+	assert.Equal(t, []Unit{UnitFt2, UnitM2, UnitDm2}, leather.CompatibleUnits())
+}
@@ -0,0 +1,85 @@
+package order
+
+import "time"
+
+// boardStatuses are the order statuses with items still being worked on.
+var boardStatuses = []StatusEnum{StatusConfirmed, StatusInProgress}
+
+type Board struct {
+	Columns []BoardColumn
+}
+
+type BoardColumn struct {
+	Progress ItemProgressEnum
+	Items    []BoardItem
+}
+
+type BoardItem struct {
+	OrderID     string
+	OrderRef    string
+	OrderNumber uint
+	OrderStatus StatusEnum
+	DueDate     time.Time
+
+	// ReadyToShip is set when all the order's items are done and it can be
+	// moved to pending shipment.
+	ReadyToShip bool
+
+	Item Item
+}
+
+// NewBoard groups the orders' items by their progress. If craftsmanID is set
+// only the items assigned to that craftsman are included.
+func NewBoard(ords []Order, craftsmanID string) *Board {
+	progresses := ItemsProgressEnums()
+	columns := make([]BoardColumn, len(progresses))
+	columnIdx := make(map[ItemProgressEnum]int, len(progresses))
+	for i, progress := range progresses {
+		columns[i].Progress = progress
+		columnIdx[progress] = i
+	}
+
+	for _, ord := range ords {
+		readyToShip := ord.ReadyToShip()
+		for _, item := range ord.Items {
+			if craftsmanID != "" && item.CraftsmanID != craftsmanID {
+				continue
+			}
+
+			progress := item.Progress
+			if progress == "" {
+				progress = ItemProgressNotStarted
+			}
+
+			idx := columnIdx[progress]
+			columns[idx].Items = append(columns[idx].Items, BoardItem{
+				OrderID:     ord.ID,
+				OrderRef:    ord.RefView(),
+				OrderNumber: ord.Number,
+				OrderStatus: ord.Status,
+				DueDate:     ord.Timeline.DueDate,
+				ReadyToShip: readyToShip,
+				Item:        item,
+			})
+		}
+	}
+
+	return &Board{Columns: columns}
+}
+
+func (o *Order) AllItemsDone() bool {
+	return allItemsDone(o) == ""
+}
+
+func (o *Order) ReadyToShip() bool {
+	return o.Status.CanMoveTo(StatusPendingShipment) && o.AllItemsDone()
+}
+
+func (o *Order) ItemByID(id string) (*Item, bool) {
+	for i := range o.Items {
+		if o.Items[i].ID == id {
+			return &o.Items[i], true
+		}
+	}
+	return nil, false
+}
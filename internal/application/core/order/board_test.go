@@ -0,0 +1,95 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBoard(t *testing.T) {
+	ords := []Order{
+		{ID: "1", Status: StatusConfirmed, Items: []Item{
+			{ID: "a", CraftsmanID: "c1"},
+			{ID: "b", Progress: ItemProgressCrafting, CraftsmanID: "c2"},
+		}},
+		{ID: "2", Status: StatusInProgress, Items: []Item{
+			{ID: "c", Progress: ItemProgressDone, CraftsmanID: "c1"},
+			{ID: "d", Progress: ItemProgressDone, CraftsmanID: "c2"},
+		}},
+	}
+
+	itemIDs := func(board *Board) map[ItemProgressEnum][]string {
+		ids := map[ItemProgressEnum][]string{}
+		for _, column := range board.Columns {
+			for _, item := range column.Items {
+				ids[column.Progress] = append(ids[column.Progress], item.Item.ID)
+			}
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name        string
+		craftsmanID string
+		want        map[ItemProgressEnum][]string
+	}{
+		{
+			"all the items",
+			"",
+			map[ItemProgressEnum][]string{
+				ItemProgressNotStarted: {"a"},
+				ItemProgressCrafting:   {"b"},
+				ItemProgressDone:       {"c", "d"},
+			},
+		},
+		{
+			"the craftsman's items",
+			"c1",
+			map[ItemProgressEnum][]string{
+				ItemProgressNotStarted: {"a"},
+				ItemProgressDone:       {"c"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := NewBoard(ords, tt.craftsmanID)
+			assert.Len(t, board.Columns, len(ItemsProgressEnums()))
+			assert.Equal(t, tt.want, itemIDs(board))
+		})
+	}
+
+	t.Run("marks the orders ready to ship", func(t *testing.T) {
+		board := NewBoard(ords, "")
+		for _, column := range board.Columns {
+			for _, item := range column.Items {
+				assert.Equal(t, item.OrderID == "2", item.ReadyToShip, item.Item.ID)
+			}
+		}
+	})
+}
+
+func TestReadyToShip(t *testing.T) {
+	done := Item{Progress: ItemProgressDone}
+	crafting := Item{Progress: ItemProgressCrafting}
+
+	tests := []struct {
+		name   string
+		status StatusEnum
+		items  []Item
+		want   bool
+	}{
+		{"in progress with all the items done", StatusInProgress, []Item{done, done}, true},
+		{"in progress with items not done", StatusInProgress, []Item{done, crafting}, false},
+		{"confirmed with all the items done", StatusConfirmed, []Item{done}, false},
+		{"already pending shipment", StatusPendingShipment, []Item{done}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ord := Order{Status: tt.status, Items: tt.items}
+			assert.Equal(t, tt.want, ord.ReadyToShip())
+		})
+	}
+}
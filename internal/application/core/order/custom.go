@@ -0,0 +1,79 @@
+package order
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/omareloui/odinls/internal/application/core/product"
+	"github.com/omareloui/odinls/internal/errs"
+)
+
+// customVariantName is the variant name of the custom items' snapshots.
+const customVariantName = "Custom"
+
+var (
+	ErrNotCustomItem   = fmt.Errorf("%w: the item isn't a custom one", errs.ErrInvalidTransition)
+	ErrAlreadyPromoted = fmt.Errorf("%w: the item is already promoted into a product", errs.ErrInvalidTransition)
+)
+
+// CustomItem is a bespoke piece that isn't in the catalog, it carries what
+// the catalog variants have.
+type CustomItem struct {
+	Name        string               `json:"name" bson:"name" conform:"trim,title" validate:"required,min=3,max=255"`
+	Description string               `json:"description" bson:"description,omitempty" conform:"trim"`
+	Category    product.CategoryEnum `json:"category" bson:"category" conform:"trim,upper" validate:"required"`
+
+	TimeToCraft   time.Duration           `json:"time_to_craft" bson:"time_to_craft,omitempty"`
+	MaterialUsage []product.MaterialUsage `json:"material_usage" bson:"material_usage,omitempty" validate:"dive"`
+
+	// Price is the unit price, it's estimated from the costs if it's not set.
+	Price float64 `json:"price" bson:"price" validate:"gte=0"`
+	// Estimate is the item's costs and margin when it got priced.
+	Estimate product.Estimate `json:"estimate" bson:"estimate"`
+
+	// PromotedProductID is the catalog product the item got promoted into.
+	PromotedProductID string `json:"promoted_product_id,omitzero" bson:"promoted_product,omitempty"`
+}
+
+// Variant is the custom item as a catalog variant, to estimate and promote
+// it the same way.
+func (c *CustomItem) Variant() *product.Variant {
+	return &product.Variant{
+		Suffix:        "std",
+		Name:          c.Name,
+		Description:   c.Description,
+		MaterialUsage: c.MaterialUsage,
+		Price:         c.Price,
+		TimeToCraft:   c.TimeToCraft,
+	}
+}
+
+// Product is the catalog product the custom item would be promoted into.
+func (c *CustomItem) Product() *product.Product {
+	return &product.Product{
+		Name:        c.Name,
+		Description: c.Description,
+		Category:    c.Category,
+		Variants:    []product.Variant{*c.Variant()},
+	}
+}
+
+func (c *CustomItem) IsPromoted() bool {
+	return c.PromotedProductID != ""
+}
+
+func (i *Item) IsCustom() bool {
+	return i.Custom != nil
+}
+
+// snapshotCustom fills the item's snapshot from its custom piece, it has no
+// product nor variant.
+func (i *Item) snapshotCustom() {
+	i.Snapshot = ItemSnapshot{
+		ProductName: i.Custom.Name,
+		Category:    i.Custom.Category,
+		VariantName: customVariantName,
+		Price:       i.Custom.Price,
+		TimeToCraft: i.Custom.TimeToCraft,
+	}
+}
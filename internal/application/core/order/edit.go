@@ -0,0 +1,97 @@
+package order
+
+import (
+	"fmt"
+
+	"github.com/omareloui/odinls/internal/errs"
+)
+
+var ErrItemStarted = fmt.Errorf("%w: the work on the item has started", errs.ErrInvalidTransition)
+
+func (i *Item) isStarted() bool {
+	return i.Progress != "" && i.Progress != ItemProgressNotStarted
+}
+
+// mergeSaved carries over what the order form doesn't send from the saved
+// order, and returns the indexes of the items that need pricing. The new items
+// and the items with a changed variant are priced, the rest keep their
+// snapshots unless refreshPrices is set. The items that are being worked on
+// can't be changed nor removed.
+func (o *Order) mergeSaved(saved *Order, refreshPrices bool) ([]int, error) {
+	toPrice := []int{}
+	kept := make(map[string]bool, len(o.Items))
+
+	for i := range o.Items {
+		item := &o.Items[i]
+		if item.ID == "" {
+			item.Progress = ItemProgressNotStarted
+			toPrice = append(toPrice, i)
+			continue
+		}
+
+		prev, ok := saved.ItemByID(item.ID)
+		if !ok {
+			return nil, errs.ErrDocumentNotFound
+		}
+		kept[item.ID] = true
+
+		if prev.isStarted() && item.changedFrom(prev) {
+			return nil, ErrItemStarted
+		}
+
+		item.Progress = prev.Progress
+		item.CraftsmanID = prev.CraftsmanID
+		item.HideIDs = prev.HideIDs
+
+		if item.IsCustom() {
+			if prev.IsCustom() {
+				item.Custom.PromotedProductID = prev.Custom.PromotedProductID
+				// An empty price is estimated, keep the estimated one.
+				if item.Custom.Price == 0 && !refreshPrices {
+					item.Custom.Price = prev.Custom.Price
+				}
+			}
+			toPrice = append(toPrice, i)
+			continue
+		}
+
+		if refreshPrices || prev.IsCustom() || item.Snapshot.VariantID != prev.Snapshot.VariantID {
+			toPrice = append(toPrice, i)
+			continue
+		}
+		item.Snapshot = prev.Snapshot
+	}
+
+	for _, prev := range saved.Items {
+		if !kept[prev.ID] && prev.isStarted() {
+			return nil, ErrItemStarted
+		}
+	}
+
+	// The payments are saved from their own forms, they're carried over for
+	// the status guards to check.
+	o.PaymentPlan = saved.PaymentPlan
+	o.Payments = saved.Payments
+
+	o.Timeline.ScheduledDate = saved.Timeline.ScheduledDate
+	o.Timeline.DoneOn = saved.Timeline.DoneOn
+	o.Timeline.ShippedOn = saved.Timeline.ShippedOn
+	o.Timeline.ResolvedOn = saved.Timeline.ResolvedOn
+	if o.Timeline.IssuanceDate.IsZero() {
+		o.Timeline.IssuanceDate = saved.Timeline.IssuanceDate
+	}
+
+	return toPrice, nil
+}
+
+// changedFrom reports whether the item is a different piece, or a different
+// quantity, than the saved one.
+func (i *Item) changedFrom(prev *Item) bool {
+	if i.IsCustom() != prev.IsCustom() || i.Quantity != prev.Quantity {
+		return true
+	}
+	if i.IsCustom() {
+		return i.Custom.Name != prev.Custom.Name || i.Custom.Category != prev.Custom.Category
+	}
+	return i.Snapshot.VariantID != prev.Snapshot.VariantID
+}
@@ -0,0 +1,135 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeSaved(t *testing.T) {
+	snapshot := func(variantID string, price float64) ItemSnapshot {
+		return ItemSnapshot{VariantID: variantID, Price: price}
+	}
+
+	saved := Order{
+		Items: []Item{
+			{ID: "a", Progress: ItemProgressNotStarted, Quantity: 1, Snapshot: snapshot("v1", 100)},
+			{ID: "b", Progress: ItemProgressCrafting, CraftsmanID: "c1", HideIDs: []string{"h1"}, Quantity: 2, Snapshot: snapshot("v2", 200)},
+			{ID: "c", Quantity: 1, Custom: &CustomItem{Name: "Custom Wallet", Category: "WALLET", Price: 300, PromotedProductID: "p1"}},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		items         []Item
+		refreshPrices bool
+		wantToPrice   []int
+		wantErr       error
+	}{
+		{
+			"unchanged items keep their snapshots",
+			[]Item{
+				{ID: "a", Quantity: 1, Snapshot: snapshot("v1", 0)},
+				{ID: "b", Quantity: 2, Snapshot: snapshot("v2", 0)},
+				{ID: "c", Quantity: 1, Custom: &CustomItem{Name: "Custom Wallet", Category: "WALLET"}},
+			},
+			false,
+			[]int{2},
+			nil,
+		},
+		{
+			"new items and changed variants are priced",
+			[]Item{
+				{ID: "a", Quantity: 3, Snapshot: snapshot("v3", 0)},
+				{ID: "b", Quantity: 2, Snapshot: snapshot("v2", 0)},
+				{ID: "c", Quantity: 1, Custom: &CustomItem{Name: "Custom Wallet", Category: "WALLET"}},
+				{Quantity: 1, Snapshot: snapshot("v1", 0)},
+			},
+			false,
+			[]int{0, 2, 3},
+			nil,
+		},
+		{
+			"refreshing prices prices them all",
+			[]Item{
+				{ID: "a", Quantity: 1, Snapshot: snapshot("v1", 0)},
+				{ID: "b", Quantity: 2, Snapshot: snapshot("v2", 0)},
+				{ID: "c", Quantity: 1, Custom: &CustomItem{Name: "Custom Wallet", Category: "WALLET"}},
+			},
+			true,
+			[]int{0, 1, 2},
+			nil,
+		},
+		{
+			"not started items can be removed",
+			[]Item{
+				{ID: "b", Quantity: 2, Snapshot: snapshot("v2", 0)},
+			},
+			false,
+			[]int{},
+			nil,
+		},
+		{
+			"started items can't be removed",
+			[]Item{
+				{ID: "a", Quantity: 1, Snapshot: snapshot("v1", 0)},
+			},
+			false,
+			nil,
+			ErrItemStarted,
+		},
+		{
+			"started items can't change their quantity",
+			[]Item{
+				{ID: "b", Quantity: 5, Snapshot: snapshot("v2", 0)},
+			},
+			false,
+			nil,
+			ErrItemStarted,
+		},
+		{
+			"started items can't change their variant",
+			[]Item{
+				{ID: "b", Quantity: 2, Snapshot: snapshot("v3", 0)},
+			},
+			false,
+			nil,
+			ErrItemStarted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ord := Order{Items: tt.items}
+			toPrice, err := ord.mergeSaved(&saved, tt.refreshPrices)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.wantToPrice, toPrice)
+			}
+		})
+	}
+
+	t.Run("carries over what the form doesn't send", func(t *testing.T) {
+		ord := Order{Items: []Item{
+			{ID: "a", Quantity: 1, Snapshot: snapshot("v1", 0)},
+			{ID: "b", Quantity: 2, Snapshot: snapshot("v2", 0)},
+			{ID: "c", Quantity: 1, Custom: &CustomItem{Name: "Custom Wallet", Category: "WALLET"}},
+			{Quantity: 1, Snapshot: snapshot("v1", 0)},
+		}}
+		_, err := ord.mergeSaved(&saved, false)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, 100.0, ord.Items[0].Snapshot.Price)
+		assert.Equal(t, ItemProgressCrafting, ord.Items[1].Progress)
+		assert.Equal(t, "c1", ord.Items[1].CraftsmanID)
+		assert.Equal(t, []string{"h1"}, ord.Items[1].HideIDs)
+		assert.Equal(t, 300.0, ord.Items[2].Custom.Price)
+		assert.Equal(t, "p1", ord.Items[2].Custom.PromotedProductID)
+		assert.Equal(t, ItemProgressNotStarted, ord.Items[3].Progress)
+	})
+}
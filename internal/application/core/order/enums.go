@@ -4,6 +4,7 @@ type (
 	PriceAddonKindEnum string
 	StatusEnum         string
 	ItemProgressEnum   string
+	PaymentMethodEnum  string
 )
 
 const (
@@ -132,3 +133,30 @@ func PriceAddonsViews() []string {
 	}
 	return priceAddons
 }
+
+const (
+	PaymentMethodCash         PaymentMethodEnum = "CASH"
+	PaymentMethodBankTransfer PaymentMethodEnum = "BANK_TRANSFER"
+	PaymentMethodWallet       PaymentMethodEnum = "WALLET"
+	PaymentMethodCard         PaymentMethodEnum = "CARD"
+)
+
+func (p PaymentMethodEnum) View() string {
+	v := map[PaymentMethodEnum]string{
+		PaymentMethodCash:         "Cash",
+		PaymentMethodBankTransfer: "Bank Transfer",
+		PaymentMethodWallet:       "Wallet",
+		PaymentMethodCard:         "Card",
+	}[p]
+	if v == "" {
+		return PaymentMethodCash.View()
+	}
+	return v
+}
+
+func PaymentMethodsEnums() []PaymentMethodEnum {
+	return []PaymentMethodEnum{
+		PaymentMethodCash, PaymentMethodBankTransfer,
+		PaymentMethodWallet, PaymentMethodCard,
+	}
+}
@@ -0,0 +1,94 @@
+package order
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/omareloui/odinls/internal/application/core/product"
+)
+
+// ClientHistory is the client's orders and what they're worth. The canceled
+// and expired orders are listed but don't count towards the totals.
+type ClientHistory struct {
+	Orders []Order
+
+	OrdersCount int
+	TotalSpent  float64
+	Outstanding float64
+	// Credit is what the client overpaid on their orders.
+	Credit float64
+
+	FirstOrder time.Time
+	LastOrder  time.Time
+
+	FavouriteCategories []CategoryCount
+}
+
+type CategoryCount struct {
+	Category product.CategoryEnum
+	Quantity int
+}
+
+// NewClientHistory sums up the client's orders.
+func NewClientHistory(orders []Order) *ClientHistory {
+	h := &ClientHistory{Orders: orders}
+
+	categories := map[product.CategoryEnum]int{}
+	for _, ord := range orders {
+		if !ord.countsForClient() {
+			continue
+		}
+
+		h.OrdersCount++
+		h.TotalSpent += ord.TotalPrice()
+		h.Outstanding += ord.RemainingAmount()
+		h.Credit += ord.Credit()
+
+		if h.FirstOrder.IsZero() || ord.CreatedAt.Before(h.FirstOrder) {
+			h.FirstOrder = ord.CreatedAt
+		}
+		if ord.CreatedAt.After(h.LastOrder) {
+			h.LastOrder = ord.CreatedAt
+		}
+
+		for _, item := range ord.Items {
+			categories[item.Snapshot.Category] += int(item.Quantity)
+		}
+	}
+
+	for category, quantity := range categories {
+		h.FavouriteCategories = append(h.FavouriteCategories, CategoryCount{Category: category, Quantity: quantity})
+	}
+	slices.SortFunc(h.FavouriteCategories, func(a, b CategoryCount) int {
+		if a.Quantity != b.Quantity {
+			return b.Quantity - a.Quantity
+		}
+		return cmp.Compare(a.Category, b.Category)
+	})
+
+	return h
+}
+
+func (h *ClientHistory) AverageOrderValue() float64 {
+	if h.OrdersCount == 0 {
+		return 0
+	}
+	return h.TotalSpent / float64(h.OrdersCount)
+}
+
+// LifetimeValues are the total spent by each client, keyed by the client's
+// ID.
+func LifetimeValues(orders []Order) map[string]float64 {
+	values := map[string]float64{}
+	for _, ord := range orders {
+		if ord.countsForClient() {
+			values[ord.ClientID] += ord.TotalPrice()
+		}
+	}
+	return values
+}
+
+func (o *Order) countsForClient() bool {
+	return o.Status != StatusCanceled && o.Status != StatusExpired
+}
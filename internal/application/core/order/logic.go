@@ -1,14 +1,17 @@
 package order
 
 import (
+	"errors"
 	"log"
 	"slices"
 	"time"
 
 	"github.com/aidarkhanov/nanoid"
 	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+	"github.com/omareloui/odinls/internal/application/core/client"
 	"github.com/omareloui/odinls/internal/application/core/counter"
 	"github.com/omareloui/odinls/internal/application/core/product"
+	"github.com/omareloui/odinls/internal/application/core/user"
 	"github.com/omareloui/odinls/internal/errs"
 	"github.com/omareloui/odinls/internal/interfaces"
 )
@@ -18,22 +21,137 @@ const (
 	refSize     = 8
 )
 
+// Hook is called after something happens to an order, like changing its
+// status. If it fails the change is reverted.
+type Hook func(claims *jwtadapter.AccessClaims, ord *Order) error
+
+// ItemHook is called after something happens to one of an order's items, like
+// updating its progress.
+type ItemHook func(claims *jwtadapter.AccessClaims, ord *Order, itemID string) error
+
 type orderService struct {
 	repo           OrderRepository
 	validator      interfaces.Validator
 	sanitizer      interfaces.Sanitizer
 	productService product.ProductService
+	clientService  client.ClientService
 	counterService counter.CounterService
+	userService    user.UserService
+
+	statusChangeHooks []Hook
+	itemProgressHooks []ItemHook
+	editHooks         []Hook
 }
 
-func NewOrderService(repo OrderRepository, productService product.ProductService, counterService counter.CounterService, validator interfaces.Validator, sanitizer interfaces.Sanitizer) *orderService {
+func NewOrderService(repo OrderRepository, productService product.ProductService, clientService client.ClientService, counterService counter.CounterService, userService user.UserService, validator interfaces.Validator, sanitizer interfaces.Sanitizer) *orderService {
 	return &orderService{
 		repo:           repo,
 		validator:      validator,
 		sanitizer:      sanitizer,
 		productService: productService,
+		clientService:  clientService,
 		counterService: counterService,
+		userService:    userService,
+	}
+}
+
+// OnStatusChange registers a hook to run after an order's status changes,
+// it's how the materials get reserved and released.
+func (s *orderService) OnStatusChange(hook Hook) {
+	s.statusChangeHooks = append(s.statusChangeHooks, hook)
+}
+
+// OnItemProgress registers a hook to run after an item's progress gets
+// updated, it's how the reserved materials get consumed.
+func (s *orderService) OnItemProgress(hook ItemHook) {
+	s.itemProgressHooks = append(s.itemProgressHooks, hook)
+}
+
+// OnEdit registers a hook to run after an order is edited from its form, it's
+// how the reserved materials follow the edited items.
+func (s *orderService) OnEdit(hook Hook) {
+	s.editHooks = append(s.editHooks, hook)
+}
+
+func (s *orderService) runStatusChangeHooks(claims *jwtadapter.AccessClaims, ord *Order) error {
+	for _, hook := range s.statusChangeHooks {
+		if err := hook(claims, ord); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *orderService) runEditHooks(claims *jwtadapter.AccessClaims, ord *Order) error {
+	for _, hook := range s.editHooks {
+		if err := hook(claims, ord); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *orderService) runItemProgressHooks(claims *jwtadapter.AccessClaims, ord *Order, itemID string) error {
+	for _, hook := range s.itemProgressHooks {
+		if err := hook(claims, ord, itemID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// afterStatusChange runs the status change hooks on the updated order, if one
+// of them fails the order is put back to the saved status so it never moves
+// on without its stock. A deposit waived along with the move is unwaived.
+func (s *orderService) afterStatusChange(claims *jwtadapter.AccessClaims, saved, updated *Order, waived bool) (*Order, error) {
+	if err := s.runStatusChangeHooks(claims, updated); err != nil {
+		revert := s.repo.UpdateOrderStatusByID
+		if waived {
+			revert = s.repo.UpdateOrderStatusUnwaivingDeposit
+		}
+		if _, rerr := revert(saved.ID, saved.Status, saved.Timeline); rerr != nil {
+			return nil, errors.Join(err, rerr)
+		}
+		return nil, err
+	}
+	return updated, nil
+}
+
+// afterEdit runs the status change hooks on the edited order if its status
+// changed, then the edit hooks. If one of them fails the order is put back as
+// it was saved, and the edit hooks run on it again so its reservations follow.
+func (s *orderService) afterEdit(claims *jwtadapter.AccessClaims, saved, updated *Order, statusChanged bool) (*Order, error) {
+	var err error
+	if statusChanged {
+		err = s.runStatusChangeHooks(claims, updated)
+	}
+	if err == nil {
+		err = s.runEditHooks(claims, updated)
+	}
+	if err == nil {
+		return updated, nil
+	}
+
+	if _, rerr := s.repo.UpdateOrderByID(saved.ID, saved); rerr != nil {
+		return nil, errors.Join(err, rerr)
+	}
+	if rerr := s.runEditHooks(claims, saved); rerr != nil {
+		return nil, errors.Join(err, rerr)
 	}
+	return nil, err
+}
+
+// afterItemProgress runs the item progress hooks on the updated order, if one
+// of them fails the item is put back to its saved progress.
+func (s *orderService) afterItemProgress(claims *jwtadapter.AccessClaims, saved, updated *Order, itemID string) (*Order, error) {
+	if err := s.runItemProgressHooks(claims, updated, itemID); err != nil {
+		item, _ := saved.ItemByID(itemID)
+		if _, rerr := s.repo.UpdateOrderItemProgress(saved.ID, itemID, item.Progress); rerr != nil {
+			return nil, errors.Join(err, rerr)
+		}
+		return nil, err
+	}
+	return updated, nil
 }
 
 func (s *orderService) GetOrders(claims *jwtadapter.AccessClaims, options ...RetrieveOptsFunc) ([]Order, error) {
@@ -44,6 +162,32 @@ func (s *orderService) GetOrders(claims *jwtadapter.AccessClaims, options ...Ret
 	return s.repo.GetOrders(options...)
 }
 
+func (s *orderService) GetClientHistory(claims *jwtadapter.AccessClaims, clientID string) (*ClientHistory, error) {
+	if claims == nil || !claims.Role.IsModerator() {
+		return nil, errs.ErrForbidden
+	}
+
+	orders, err := s.repo.GetOrdersByClientID(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClientHistory(orders), nil
+}
+
+func (s *orderService) GetClientsLifetimeValues(claims *jwtadapter.AccessClaims) (map[string]float64, error) {
+	if claims == nil || !claims.Role.IsModerator() {
+		return nil, errs.ErrForbidden
+	}
+
+	orders, err := s.repo.GetOrders()
+	if err != nil {
+		return nil, err
+	}
+
+	return LifetimeValues(orders), nil
+}
+
 func (s *orderService) GetOrderByID(claims *jwtadapter.AccessClaims, id string, options ...RetrieveOptsFunc) (*Order, error) {
 	if claims == nil || !claims.Role.IsModerator() {
 		return nil, errs.ErrForbidden
@@ -52,11 +196,165 @@ func (s *orderService) GetOrderByID(claims *jwtadapter.AccessClaims, id string,
 	return s.repo.GetOrderByID(id, options...)
 }
 
+// GetMyOrders gets the orders of the logged in user's client record.
+func (s *orderService) GetMyOrders(claims *jwtadapter.AccessClaims) ([]Order, error) {
+	cli, err := s.clientService.GetMyClient(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetOrdersByClientID(cli.ID)
+}
+
+// GetMyOrder gets one of the logged in user's orders, the other clients'
+// orders are reported as not found.
+func (s *orderService) GetMyOrder(claims *jwtadapter.AccessClaims, id string, options ...RetrieveOptsFunc) (*Order, error) {
+	cli, err := s.clientService.GetMyClient(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	ord, err := s.repo.GetOrderByID(id, options...)
+	if err != nil {
+		return nil, err
+	}
+	if ord.ClientID != cli.ID {
+		return nil, errs.ErrDocumentNotFound
+	}
+
+	return ord, nil
+}
+
+func (s *orderService) GetOrderByRef(claims *jwtadapter.AccessClaims, ref string, options ...RetrieveOptsFunc) (*Order, error) {
+	if claims == nil || !claims.Role.IsModerator() {
+		return nil, errs.ErrForbidden
+	}
+
+	return s.repo.GetOrderByRef(NormalizeRef(ref), options...)
+}
+
+func (s *orderService) GetOrderTrackingByRef(ref string) (*Tracking, error) {
+	ref = NormalizeRef(ref)
+	if len(ref) != refSize {
+		return nil, errs.ErrDocumentNotFound
+	}
+
+	ord, err := s.repo.GetOrderByRef(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return ord.Tracking(), nil
+}
+
 func (s *orderService) CreateOrder(claims *jwtadapter.AccessClaims, ord *Order, options ...RetrieveOptsFunc) (*Order, error) {
 	if claims == nil || !claims.Role.IsAdmin() || !claims.IsCraftsman() {
 		return nil, errs.ErrForbidden
 	}
 
+	if err := s.PriceItems(claims, ord.ClientID, ord.Items); err != nil {
+		return nil, err
+	}
+
+	return s.createOrder(claims, ord, options...)
+}
+
+// CreateQuotedOrder creates the order keeping its items' snapshots as they
+// are, so the prices stay as they were quoted. The created order is passed to
+// claim to mark its quote as converted, if claiming fails the order is
+// deleted so a quote never ends up with two orders.
+func (s *orderService) CreateQuotedOrder(claims *jwtadapter.AccessClaims, ord *Order, claim func(orderID string) error, options ...RetrieveOptsFunc) (*Order, error) {
+	if claims == nil || !claims.Role.IsAdmin() || !claims.IsCraftsman() {
+		return nil, errs.ErrForbidden
+	}
+
+	created, err := s.createOrder(claims, ord, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := claim(created.ID); err != nil {
+		if derr := s.repo.DeleteOrderByID(created.ID); derr != nil {
+			return nil, errors.Join(err, derr)
+		}
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// PriceItems fills the items' snapshots from their variants, with the prices
+// of the client's tier.
+func (s *orderService) PriceItems(claims *jwtadapter.AccessClaims, clientID string, items []Item) error {
+	cli, err := s.clientService.GetClientByID(claims, clientID)
+	if err != nil {
+		return err
+	}
+	tier := cli.Tier()
+
+	for i := range items {
+		if err := s.priceItem(claims, tier, &items[i]); err != nil {
+			return &ItemError{Index: i, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// priceItem fills the item's snapshot from its variant, with the price of the
+// tier.
+func (s *orderService) priceItem(claims *jwtadapter.AccessClaims, tier product.PriceTierEnum, item *Item) error {
+	if item.IsCustom() {
+		return s.priceCustomItem(claims, item)
+	}
+
+	prod, err := s.productService.GetProductByVariantID(claims, item.Snapshot.VariantID)
+	if err != nil {
+		return err
+	}
+
+	variantIdx := slices.IndexFunc(prod.Variants, func(v product.Variant) bool {
+		return v.ID == item.Snapshot.VariantID
+	})
+	if variantIdx == -1 {
+		log.Fatalln("invalid variant index: (searching a variant after getting it back by searching for a product with its id and its variant id)")
+	}
+	variant := prod.Variants[variantIdx]
+
+	item.Snapshot.ProductID = prod.ID
+	item.Snapshot.ProductName = prod.Name
+	item.Snapshot.Category = prod.Category
+
+	item.Snapshot.SKU = variant.SKU()
+	item.Snapshot.VariantName = variant.Name
+	item.Snapshot.Options = variant.Options
+
+	item.Snapshot.Price = variant.PriceFor(tier, item.Quantity)
+	item.Snapshot.PriceTier = tier
+
+	item.Snapshot.TimeToCraft = variant.TimeToCraft
+
+	return nil
+}
+
+// priceCustomItem estimates the custom item's costs the same way the catalog
+// variants are, and snapshots it.
+func (s *orderService) priceCustomItem(claims *jwtadapter.AccessClaims, item *Item) error {
+	v := item.Custom.Variant()
+	estimate, err := s.productService.EstimateVariant(claims, v)
+	if err != nil {
+		return err
+	}
+
+	item.Custom.MaterialUsage = v.MaterialUsage
+	item.Custom.Price = v.Price
+	item.Custom.Estimate = *estimate
+	item.snapshotCustom()
+
+	return nil
+}
+
+func (s *orderService) createOrder(claims *jwtadapter.AccessClaims, ord *Order, options ...RetrieveOptsFunc) (*Order, error) {
 	ord.Ref, _ = nanoid.Generate(refAlphabet, refSize)
 
 	num, err := s.counterService.AddOneToOrder(claims)
@@ -69,60 +367,403 @@ func (s *orderService) CreateOrder(claims *jwtadapter.AccessClaims, ord *Order,
 		ord.Timeline.IssuanceDate = time.Now()
 	}
 
-	for i, item := range ord.Items {
-		prod, err := s.productService.GetProductByVariantID(claims, item.Snapshot.VariantID)
+	for i := range ord.Items {
+		ord.Items[i].Progress = ItemProgressNotStarted
+	}
+
+	err = s.sanitizer.SanitizeStruct(ord)
+	if err != nil {
+		return nil, errs.ErrSanitizer
+	}
+
+	if err := s.validator.Validate(ord); err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateOrder(ord, options...)
+}
+
+// UpdateOrderByID updates the order from its form. The saved items keep
+// their snapshots unless refreshPrices is set, the new items and the items
+// with a changed variant are priced with the client's current tier.
+func (s *orderService) UpdateOrderByID(claims *jwtadapter.AccessClaims, id string, uord *Order, refreshPrices bool, options ...RetrieveOptsFunc) (*Order, error) {
+	if claims == nil || !claims.Role.IsAdmin() || !claims.IsCraftsman() {
+		return nil, errs.ErrForbidden
+	}
+
+	ord, err := s.repo.GetOrderByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	toPrice, err := uord.mergeSaved(ord, refreshPrices)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(toPrice) > 0 {
+		cli, err := s.clientService.GetClientByID(claims, uord.ClientID)
 		if err != nil {
 			return nil, err
 		}
+		for _, i := range toPrice {
+			if err := s.priceItem(claims, cli.Tier(), &uord.Items[i]); err != nil {
+				return nil, &ItemError{Index: i, Err: err}
+			}
+		}
+	}
+
+	err = s.sanitizer.SanitizeStruct(uord)
+	if err != nil {
+		return nil, errs.ErrSanitizer
+	}
+
+	if err := s.validator.Validate(uord); err != nil {
+		return nil, err
+	}
 
-		variantIdx := slices.IndexFunc(prod.Variants, func(v product.Variant) bool {
-			return v.ID == ord.Items[i].Snapshot.VariantID
-		})
-		if variantIdx == -1 {
-			log.Fatalln("invalid variant index: (searching a variant after getting it back by searching for a product with its id and its variant id)")
+	statusChanged := uord.Status != ord.Status
+	if statusChanged {
+		to := uord.Status
+		uord.Status = ord.Status
+		if err := uord.TransitionTo(to, time.Now()); err != nil {
+			return nil, err
 		}
-		variant := prod.Variants[variantIdx]
+	}
 
-		ord.Items[i].Snapshot.ProductID = prod.ID
-		ord.Items[i].Snapshot.ProductName = prod.Name
-		ord.Items[i].Snapshot.Category = prod.Category
+	updated, err := s.repo.UpdateOrderByID(id, uord, options...)
+	if err != nil {
+		return nil, err
+	}
 
-		ord.Items[i].Snapshot.SKU = variant.SKU()
-		ord.Items[i].Snapshot.VariantName = variant.Name
-		ord.Items[i].Snapshot.Options = variant.Options
+	return s.afterEdit(claims, ord, updated, statusChanged)
+}
 
-		ord.Items[i].Snapshot.Price = variant.Price
+func (s *orderService) TransitionOrderStatus(claims *jwtadapter.AccessClaims, id string, to StatusEnum, options ...RetrieveOptsFunc) (*Order, error) {
+	return s.transitionOrderStatus(claims, id, to, false, options...)
+}
 
-		ord.Items[i].Snapshot.TimeToCraft = variant.TimeToCraft
+// StartOrderWaivingDeposit starts the work on the order before its deposit is
+// received, the waiver is only recorded along with the start.
+func (s *orderService) StartOrderWaivingDeposit(claims *jwtadapter.AccessClaims, id string, options ...RetrieveOptsFunc) (*Order, error) {
+	return s.transitionOrderStatus(claims, id, StatusInProgress, true, options...)
+}
 
-		ord.Items[i].Progress = ItemProgressNotStarted
+func (s *orderService) transitionOrderStatus(claims *jwtadapter.AccessClaims, id string, to StatusEnum, waiveDeposit bool, options ...RetrieveOptsFunc) (*Order, error) {
+	if claims == nil || !claims.Role.IsAdmin() || !claims.IsCraftsman() {
+		return nil, errs.ErrForbidden
 	}
 
-	err = s.sanitizer.SanitizeStruct(ord)
+	ord, err := s.repo.GetOrderByID(id)
 	if err != nil {
-		return nil, errs.ErrSanitizer
+		return nil, err
 	}
 
-	if err := s.validator.Validate(ord); err != nil {
+	saved := *ord
+	waived := waiveDeposit && ord.PaymentPlan != nil && !ord.DepositReceived() && ord.PaymentPlan.DepositWaivedBy == ""
+	if waived {
+		plan := *ord.PaymentPlan
+		plan.DepositWaivedBy = claims.ID
+		ord.PaymentPlan = &plan
+	}
+
+	if err := ord.TransitionTo(to, time.Now()); err != nil {
 		return nil, err
 	}
 
-	return s.repo.CreateOrder(ord, options...)
+	var updated *Order
+	if waived {
+		updated, err = s.repo.UpdateOrderStatusWaivingDeposit(id, ord.Status, ord.Timeline, claims.ID, options...)
+	} else {
+		updated, err = s.repo.UpdateOrderStatusByID(id, ord.Status, ord.Timeline, options...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.afterStatusChange(claims, &saved, updated, waived)
+}
+
+func (s *orderService) GetBoard(claims *jwtadapter.AccessClaims) (*Board, error) {
+	if claims == nil || !claims.IsCraftsman() {
+		return nil, errs.ErrForbidden
+	}
+
+	ords, err := s.repo.GetOrdersByStatuses(boardStatuses)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Role.IsAdmin() {
+		return NewBoard(ords, ""), nil
+	}
+	return NewBoard(ords, claims.ID), nil
 }
 
-func (s *orderService) UpdateOrderByID(claims *jwtadapter.AccessClaims, id string, uord *Order, options ...RetrieveOptsFunc) (*Order, error) {
+func (s *orderService) UpdateItemProgress(claims *jwtadapter.AccessClaims, orderID, itemID string, progress ItemProgressEnum, options ...RetrieveOptsFunc) (*Order, error) {
+	if claims == nil || !claims.IsCraftsman() {
+		return nil, errs.ErrForbidden
+	}
+
+	if !slices.Contains(ItemsProgressEnums(), progress) {
+		return nil, errs.ErrInvalidEnum
+	}
+
+	ord, err := s.repo.GetOrderByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, ok := ord.ItemByID(itemID)
+	if !ok {
+		return nil, errs.ErrDocumentNotFound
+	}
+
+	if !claims.Role.IsAdmin() && item.CraftsmanID != claims.ID {
+		return nil, errs.ErrForbidden
+	}
+
+	if !slices.Contains(boardStatuses, ord.Status) {
+		return nil, errs.ErrInvalidTransition
+	}
+
+	// Starting an item of a confirmed order starts the order, so no work is
+	// done on it before its deposit is received.
+	if ord.Status == StatusConfirmed && progress != ItemProgressNotStarted {
+		if err := s.startOrder(claims, ord); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := s.repo.UpdateOrderItemProgress(orderID, itemID, progress, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.afterItemProgress(claims, ord, updated, itemID)
+}
+
+// startOrder moves the order to in progress, it's put back if the status
+// change hooks fail.
+func (s *orderService) startOrder(claims *jwtadapter.AccessClaims, ord *Order) error {
+	started := *ord
+	if err := started.TransitionTo(StatusInProgress, time.Now()); err != nil {
+		return err
+	}
+
+	updated, err := s.repo.UpdateOrderStatusByID(ord.ID, started.Status, started.Timeline)
+	if err != nil {
+		return err
+	}
+	_, err = s.afterStatusChange(claims, ord, updated, false)
+	return err
+}
+
+func (s *orderService) AssignItemCraftsman(claims *jwtadapter.AccessClaims, orderID, itemID, craftsmanID string, options ...RetrieveOptsFunc) (*Order, error) {
 	if claims == nil || !claims.Role.IsAdmin() || !claims.IsCraftsman() {
 		return nil, errs.ErrForbidden
 	}
 
-	err := s.sanitizer.SanitizeStruct(uord)
+	if craftsmanID != "" {
+		usr, err := s.userService.GetUserByID(craftsmanID)
+		if err != nil {
+			return nil, err
+		}
+		if !usr.IsCraftsman() {
+			return nil, errs.ErrInvalidID
+		}
+	}
+
+	ord, err := s.repo.GetOrderByID(orderID)
 	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := ord.ItemByID(itemID); !ok {
+		return nil, errs.ErrDocumentNotFound
+	}
+
+	if ord.Status.IsFinal() {
+		return nil, errs.ErrInvalidTransition
+	}
+
+	return s.repo.UpdateOrderItemCraftsman(orderID, itemID, craftsmanID, options...)
+}
+
+// PickItemHides sets the hides the item is cut from, the item's leather is
+// taken out of them once it's done.
+func (s *orderService) PickItemHides(claims *jwtadapter.AccessClaims, orderID, itemID string, hideIDs []string, options ...RetrieveOptsFunc) (*Order, error) {
+	if claims == nil || !claims.IsCraftsman() {
+		return nil, errs.ErrForbidden
+	}
+
+	ord, err := s.repo.GetOrderByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, ok := ord.ItemByID(itemID)
+	if !ok {
+		return nil, errs.ErrDocumentNotFound
+	}
+
+	if !claims.Role.IsAdmin() && item.CraftsmanID != claims.ID {
+		return nil, errs.ErrForbidden
+	}
+
+	if item.Progress == ItemProgressDone || !slices.Contains(boardStatuses, ord.Status) {
+		return nil, errs.ErrInvalidTransition
+	}
+
+	item.HideIDs = slices.Compact(slices.Sorted(slices.Values(hideIDs)))
+	if err := s.validator.Validate(item); err != nil {
+		return nil, err
+	}
+
+	return s.repo.UpdateOrderItemHides(orderID, itemID, item.HideIDs, options...)
+}
+
+func (s *orderService) RecordPayment(claims *jwtadapter.AccessClaims, orderID string, payment *Payment, options ...RetrieveOptsFunc) (*Order, error) {
+	return s.addPayment(claims, orderID, payment, false, options...)
+}
+
+// RecordRefund records the refund as a negative payment, it can't refund more
+// than what the client paid.
+func (s *orderService) RecordRefund(claims *jwtadapter.AccessClaims, orderID string, refund *Payment, options ...RetrieveOptsFunc) (*Order, error) {
+	return s.addPayment(claims, orderID, refund, true, options...)
+}
+
+func (s *orderService) addPayment(claims *jwtadapter.AccessClaims, orderID string, payment *Payment, isRefund bool, options ...RetrieveOptsFunc) (*Order, error) {
+	if claims == nil || !claims.Role.IsAdmin() {
+		return nil, errs.ErrForbidden
+	}
+
+	if err := s.sanitizer.SanitizeStruct(payment); err != nil {
 		return nil, errs.ErrSanitizer
 	}
 
-	if err := s.validator.Validate(uord); err != nil {
+	if err := s.validator.Validate(payment); err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(PaymentMethodsEnums(), payment.Method) {
+		return nil, errs.ErrInvalidEnum
+	}
+
+	if isRefund {
+		if payment.Reason == "" {
+			return nil, ErrRefundWithoutReason
+		}
+		payment.Amount = -payment.Amount
+	}
+
+	now := time.Now()
+	if payment.Date.IsZero() {
+		payment.Date = now
+	}
+	payment.CreatedAt = now
+	payment.ReceivedByID = claims.ID
+	payment.ReceivedByName = claims.Name.FullName()
+
+	// The payment is numbered after the ones the order has, if another
+	// payment gets added in the meantime it's numbered again.
+	for range paymentAttempts {
+		ord, err := s.repo.GetOrderByID(orderID)
+		if err != nil {
+			return nil, err
+		}
+
+		if isRefund && -payment.Amount > ord.Paid() {
+			return nil, ErrRefundOverPaid
+		}
+
+		payment.Number = 0
+		for _, p := range ord.Payments {
+			payment.Number = max(payment.Number, p.Number)
+		}
+		payment.Number++
+
+		updated, err := s.repo.AddOrderPayment(orderID, payment, len(ord.Payments), options...)
+		if !errors.Is(err, errs.ErrDocumentNotFound) {
+			return updated, err
+		}
+	}
+
+	return nil, ErrPaymentsChanged
+}
+
+// SetPaymentPlan sets the deposit and the instalments of the order, an empty
+// plan removes it.
+func (s *orderService) SetPaymentPlan(claims *jwtadapter.AccessClaims, orderID string, plan *PaymentPlan, options ...RetrieveOptsFunc) (*Order, error) {
+	if claims == nil || !claims.Role.IsAdmin() {
+		return nil, errs.ErrForbidden
+	}
+
+	if err := s.validator.Validate(plan); err != nil {
+		return nil, err
+	}
+
+	ord, err := s.repo.GetOrderByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if plan.isEmpty() {
+		return s.repo.SetOrderPaymentPlan(orderID, nil, options...)
+	}
+
+	if err := plan.fitsOrder(ord); err != nil {
+		return nil, err
+	}
+
+	if ord.PaymentPlan != nil {
+		plan.DepositWaivedBy = ord.PaymentPlan.DepositWaivedBy
+	}
+
+	return s.repo.SetOrderPaymentPlan(orderID, plan, options...)
+}
+
+func (s *orderService) GetReceivables(claims *jwtadapter.AccessClaims) ([]Receivable, error) {
+	if claims == nil || !claims.Role.IsModerator() {
+		return nil, errs.ErrForbidden
+	}
+
+	orders, err := s.repo.GetOrdersWithInstalments(WithPopulatedClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return OverdueInstalments(orders, time.Now()), nil
+}
+
+// PromoteCustomItem adds the custom item to the catalog as a new product, the
+// item keeps its price and points to the product.
+func (s *orderService) PromoteCustomItem(claims *jwtadapter.AccessClaims, orderID, itemID string, options ...RetrieveOptsFunc) (*Order, error) {
+	if claims == nil || !claims.Role.IsAdmin() || !claims.IsCraftsman() {
+		return nil, errs.ErrForbidden
+	}
+
+	ord, err := s.repo.GetOrderByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, ok := ord.ItemByID(itemID)
+	if !ok {
+		return nil, errs.ErrDocumentNotFound
+	}
+
+	if !item.IsCustom() {
+		return nil, ErrNotCustomItem
+	}
+	if item.Custom.IsPromoted() {
+		return nil, ErrAlreadyPromoted
+	}
+
+	prod, err := s.productService.CreateProduct(claims, item.Custom.Product())
+	if err != nil {
 		return nil, err
 	}
 
-	return s.repo.UpdateOrderByID(id, uord, options...)
+	return s.repo.UpdateOrderItemPromotedProduct(orderID, itemID, prod.ID, options...)
 }
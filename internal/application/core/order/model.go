@@ -26,7 +26,12 @@ type Order struct {
 	Items       []Item       `json:"items" bson:"items" validate:"required,min=1,dive"`
 	PriceAddons []PriceAddon `json:"price_addons" bson:"price_addons,omitempty" validate:"dive"`
 
-	ReceivedAmounts []ReceivedAmount `json:"received_amounts" bson:"received_amounts,omitempty" validate:"dive"`
+	// Payments is the ledger of what the client paid and got refunded, it's
+	// only added to so the full order updates leave it out.
+	Payments []Payment `json:"payments" bson:"payments,omitempty" validate:"-"`
+	// PaymentPlan is set from its own form, so the full order updates leave it
+	// out too.
+	PaymentPlan *PaymentPlan `json:"payment_plan,omitzero" bson:"payment_plan,omitempty" validate:"-"`
 
 	Note string `json:"note" bson:"note,omitempty"`
 
@@ -45,7 +50,7 @@ type PriceAddon struct {
 }
 
 type Timeline struct {
-	IssuanceDate  time.Time `json:"issuance_date" bson:"issuance_date" validate:"required,gte=now"`
+	IssuanceDate  time.Time `json:"issuance_date" bson:"issuance_date" validate:"required"`
 	ScheduledDate time.Time `json:"scheduled_date,omitzero" bson:"scheduled_date,omitempty" validate:"omitempty,gtfield=IssuanceDate"`
 	DoneOn        time.Time `json:"done_on,omitzero" bson:"done_on,omitempty" validate:"omitempty,gtfield=IssuanceDate"`
 	ShippedOn     time.Time `json:"shipped_on,omitzero" bson:"shipped_on,omitempty" validate:"omitempty,gtfield=IssuanceDate"`
@@ -53,38 +58,56 @@ type Timeline struct {
 	DueDate       time.Time `json:"due_date,omitzero" bson:"due_date,omitempty" validate:"omitempty,gtfield=IssuanceDate"`
 }
 
-type ReceivedAmount struct {
-	Amount float64   `json:"amount" bson:"amount" validate:"required,lte=0"`
-	Date   time.Time `json:"date" bson:"date" validate:"required"`
-}
-
 type Item struct {
 	ID       string           `json:"id" bson:"_id,omitempty" validate:"omitempty,mongodb"`
 	Progress ItemProgressEnum `json:"progress" bson:"progress" validate:"omitempty"`
 
 	CraftsmanID string `json:"craftsman_id,omitzero" bson:"craftsman,omitempty" validate:"omitempty,mongodb"`
 
+	// HideIDs are the leather hides the item is cut from, to trace the
+	// finished item back to them.
+	HideIDs []string `json:"hide_ids" bson:"hides,omitempty" validate:"omitempty,dive,mongodb"`
+
 	CustomUnitPrice float64 `json:"custom_price" bson:"custom_price" validate:"gte=0"`
 	Quantity        uint16  `json:"quantity" bson:"quantity"`
 
 	Snapshot ItemSnapshot `json:"snapshot" bson:"snapshot,omitempty"`
+	// Custom is set for the bespoke items that aren't in the catalog.
+	Custom *CustomItem `json:"custom,omitzero" bson:"custom,omitempty" validate:"omitempty"`
 
-	Product   *product.Product `json:"product" bson:"populated_product"`
+	Product   *product.Product `json:"product" bson:"populated_product,omitempty"`
 	Craftsman *user.User       `json:"craftsman" bson:"populated_craftsman,omitempty"`
 }
 
+// ItemError is an error with one of the order's items.
+type ItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("item #%d: %s", e.Index+1, e.Err)
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
 type ItemSnapshot struct {
 	ProductID string `json:"product_id" bson:"product,omitempty"`
 
 	ProductName string               `json:"name" bson:"name,omitempty" conform:"trim,title" validate:"required,min=3,max=255"`
 	Category    product.CategoryEnum `json:"category" bson:"category,omitempty" conform:"trim,upper" validate:"required"`
 
-	VariantID   string            `json:"variant_id" bson:"variant_id,omitempty" validate:"required,mongodb"`
+	// VariantID is empty for the custom items.
+	VariantID   string            `json:"variant_id" bson:"variant_id,omitempty" validate:"omitempty,mongodb"`
 	VariantName string            `json:"variant_name" bson:"variant_name,omitempty" conform:"trim,title" validate:"required,min=3,max=255"`
 	SKU         string            `json:"sku" bson:"sku,omitempty"`
 	Options     map[string]string `json:"options" bson:"options,omitempty"`
 
 	Price float64 `json:"price" bson:"price" validate:"required,gte=0"`
+	// PriceTier is the client's tier the price was taken from.
+	PriceTier product.PriceTierEnum `json:"price_tier" bson:"price_tier,omitempty"`
 
 	TimeToCraft time.Duration `json:"time_to_craft" bson:"time_to_craft,omitempty"`
 }
@@ -122,63 +145,96 @@ func (o *Order) TimeToCraft() time.Duration {
 	return duration
 }
 
-func (o *Order) TotalPrice() float64 {
-	var total float64
+// AppliedPriceAddon is a price addon with the signed value it adds to the
+// order's total.
+type AppliedPriceAddon struct {
+	PriceAddon
+	Value float64
+}
 
-	var subtotal float64 = o.Subtotal()
+// AppliedPriceAddons returns the price addons in the order they're applied.
+// Absolute amounts come first, then the fees, shipping, and discount
+// percentages of the subtotal, and finally the taxes percentage of everything
+// before it. Only the last percentage of each kind is applied.
+func (o *Order) AppliedPriceAddons() []AppliedPriceAddon {
+	subtotal := o.Subtotal()
+	applied := []AppliedPriceAddon{}
 
-	var discountsPercentage float64
-	var taxesPercentage float64
-	var feesPercentage float64
-	var shippingPercentage float64
+	percentages := map[PriceAddonKindEnum]PriceAddon{}
 
 	for _, addon := range o.PriceAddons {
-		if !addon.IsPercentage {
-			if addon.Kind == PriceAddonKindDiscount {
-				total -= addon.Amount
-			} else {
-				total += addon.Amount
-			}
+		if addon.IsPercentage {
+			percentages[addon.Kind] = addon
 			continue
 		}
 
-		switch addon.Kind {
-		case PriceAddonKindTaxes:
-			taxesPercentage = addon.Amount / 100
-		case PriceAddonKindFees:
-			feesPercentage = addon.Amount / 100
-		case PriceAddonKindShipping:
-			shippingPercentage = addon.Amount / 100
-		case PriceAddonKindDiscount:
-			discountsPercentage = addon.Amount / 100
+		value := addon.Amount
+		if addon.Kind == PriceAddonKindDiscount {
+			value = -value
+		}
+		applied = append(applied, AppliedPriceAddon{PriceAddon: addon, Value: value})
+	}
+
+	for _, kind := range []PriceAddonKindEnum{PriceAddonKindFees, PriceAddonKindShipping, PriceAddonKindDiscount} {
+		addon, ok := percentages[kind]
+		if !ok {
+			continue
 		}
+		value := subtotal * addon.Amount / 100
+		if kind == PriceAddonKindDiscount {
+			value = -value
+		}
+		applied = append(applied, AppliedPriceAddon{PriceAddon: addon, Value: value})
 	}
 
-	total += subtotal
-	total += subtotal * feesPercentage
-	total += subtotal * shippingPercentage
-	total -= subtotal * discountsPercentage
+	if addon, ok := percentages[PriceAddonKindTaxes]; ok {
+		total := subtotal
+		for _, a := range applied {
+			total += a.Value
+		}
+		applied = append(applied, AppliedPriceAddon{PriceAddon: addon, Value: total * addon.Amount / 100})
+	}
 
-	total += total * taxesPercentage
+	return applied
+}
 
+func (o *Order) TotalPrice() float64 {
+	total := o.Subtotal()
+	for _, addon := range o.AppliedPriceAddons() {
+		total += addon.Value
+	}
 	return total
 }
 
-func (o *Order) RemainingAmount() float64 {
+// Paid is what the client paid after the refunds.
+func (o *Order) Paid() float64 {
 	var paid float64
-	for _, received := range o.ReceivedAmounts {
-		paid += received.Amount
+	for _, payment := range o.Payments {
+		paid += payment.Amount
 	}
-	return o.TotalPrice() - paid
+	return paid
+}
+
+func (o *Order) RemainingAmount() float64 {
+	return max(o.TotalPrice()-o.Paid(), 0)
+}
+
+// Credit is what the client paid over the order's total.
+func (o *Order) Credit() float64 {
+	return max(o.Paid()-o.TotalPrice(), 0)
 }
 
 func (o *Order) NotFullyPaid() bool {
 	return o.RemainingAmount() > 0
 }
 
-func (i *Item) TotalPrice() float64 {
+func (i *Item) UnitPrice() float64 {
 	if i.CustomUnitPrice > 0 {
-		return i.CustomUnitPrice * float64(i.Quantity)
+		return i.CustomUnitPrice
 	}
-	return i.Snapshot.Price * float64(i.Quantity)
+	return i.Snapshot.Price
+}
+
+func (i *Item) TotalPrice() float64 {
+	return i.UnitPrice() * float64(i.Quantity)
 }
@@ -0,0 +1,59 @@
+package order
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/omareloui/odinls/internal/errs"
+)
+
+var (
+	ErrRefundWithoutReason = fmt.Errorf("%w: a refund needs a reason", errs.ErrInvalidPayment)
+	ErrRefundOverPaid      = fmt.Errorf("%w: can't refund more than what was paid", errs.ErrInvalidPayment)
+	ErrPaymentsChanged     = fmt.Errorf("%w: other payments are being recorded, try again", errs.ErrInvalidPayment)
+)
+
+// paymentAttempts is how many times a payment is tried to be added while
+// other payments are being added to the order.
+const paymentAttempts = 3
+
+// Payment is an entry in the order's payments ledger, the refunds are entries
+// with a negative amount.
+type Payment struct {
+	ID string `json:"id" bson:"_id,omitempty" formfield:"-"`
+	// Number is the payment's number in the order, it's the receipt's number
+	// with the order's number.
+	Number uint `json:"number" bson:"number" formfield:"-"`
+
+	Amount    float64           `json:"amount" bson:"amount" formfield:"amount" validate:"required,gt=0"`
+	Method    PaymentMethodEnum `json:"method" bson:"method" formfield:"method" conform:"trim,upper" validate:"required"`
+	Reference string            `json:"reference" bson:"reference,omitempty" formfield:"reference" conform:"trim" validate:"max=255"`
+	// Reason is why the refund was made.
+	Reason string    `json:"reason" bson:"reason,omitempty" formfield:"reason" conform:"trim" validate:"max=255"`
+	Date   time.Time `json:"date" bson:"date" formfield:"date"`
+
+	ReceivedByID   string `json:"received_by_id" bson:"received_by" formfield:"-"`
+	ReceivedByName string `json:"received_by_name" bson:"received_by_name" formfield:"-"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at" formfield:"-"`
+}
+
+func (p *Payment) IsRefund() bool {
+	return p.Amount < 0
+}
+
+// ReceiptNumber is the order's number followed by the payment's number in it.
+func (p *Payment) ReceiptNumber(ord *Order) string {
+	return fmt.Sprintf("%d-%d", ord.Number, p.Number)
+}
+
+func (o *Order) PaymentByID(id string) (*Payment, bool) {
+	idx := slices.IndexFunc(o.Payments, func(p Payment) bool {
+		return p.ID == id
+	})
+	if idx == -1 {
+		return nil, false
+	}
+	return &o.Payments[idx], true
+}
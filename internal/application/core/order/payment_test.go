@@ -0,0 +1,52 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaymentsLedger(t *testing.T) {
+	items := []Item{{Snapshot: ItemSnapshot{Price: 500}, Quantity: 2}}
+
+	tests := []struct {
+		name          string
+		payments      []Payment
+		wantPaid      float64
+		wantRemaining float64
+		wantCredit    float64
+	}{
+		{"nothing paid", nil, 0, 1000, 0},
+		{"partly paid", []Payment{{Amount: 300}, {Amount: 200}}, 500, 500, 0},
+		{"fully paid", []Payment{{Amount: 600}, {Amount: 400}}, 1000, 0, 0},
+		{"refund is taken out", []Payment{{Amount: 1000}, {Amount: -250}}, 750, 250, 0},
+		{"paid over the total", []Payment{{Amount: 800}, {Amount: 350}}, 1150, 0, 150},
+		{"refunded the credit", []Payment{{Amount: 800}, {Amount: 350}, {Amount: -150}}, 1000, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ord := Order{Items: items, Payments: tt.payments}
+			assert.Equal(t, tt.wantPaid, ord.Paid())
+			assert.Equal(t, tt.wantRemaining, ord.RemainingAmount())
+			assert.Equal(t, tt.wantCredit, ord.Credit())
+			assert.Equal(t, tt.wantRemaining > 0, ord.NotFullyPaid())
+		})
+	}
+}
+
+func TestPaymentNumbers(t *testing.T) {
+	ord := Order{
+		Number:   42,
+		Payments: []Payment{{ID: "a", Number: 1, Amount: 100}, {ID: "b", Number: 2, Amount: -50}},
+	}
+
+	payment, ok := ord.PaymentByID("b")
+	if assert.True(t, ok) {
+		assert.Equal(t, "42-2", payment.ReceiptNumber(&ord))
+		assert.True(t, payment.IsRefund())
+	}
+
+	_, ok = ord.PaymentByID("c")
+	assert.False(t, ok)
+}
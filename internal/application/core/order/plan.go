@@ -0,0 +1,152 @@
+package order
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/omareloui/odinls/internal/errs"
+)
+
+var ErrPlanOverTotal = fmt.Errorf("%w: the payment plan is more than the order's total", errs.ErrInvalidPayment)
+
+// PaymentPlan is the deposit to take before starting the work on the order,
+// and the instalments to pay the rest by.
+type PaymentPlan struct {
+	// Deposit is an amount, or a percentage of the order's total if
+	// DepositIsPercentage.
+	Deposit             float64 `json:"deposit" bson:"deposit" validate:"gte=0"`
+	DepositIsPercentage bool    `json:"deposit_is_percentage" bson:"deposit_is_percentage"`
+	// DepositWaivedBy is the admin who let the work start before the deposit
+	// was received.
+	DepositWaivedBy string `json:"deposit_waived_by,omitzero" bson:"deposit_waived_by,omitempty"`
+
+	Instalments []Instalment `json:"instalments" bson:"instalments,omitempty" validate:"dive"`
+}
+
+type Instalment struct {
+	Amount  float64   `json:"amount" bson:"amount" validate:"required,gt=0"`
+	DueDate time.Time `json:"due_date" bson:"due_date" validate:"required"`
+}
+
+// ScheduledInstalment is an instalment with what got paid of it, the payments
+// after the deposit go to the instalments by their due dates.
+type ScheduledInstalment struct {
+	Instalment
+	Paid float64
+}
+
+func (i ScheduledInstalment) Outstanding() float64 {
+	return max(i.Amount-i.Paid, 0)
+}
+
+// IsOverdue tells if the instalment isn't fully paid after the day it's due.
+func (i ScheduledInstalment) IsOverdue(now time.Time) bool {
+	return i.Outstanding() > 0 && daysOverdue(i.DueDate, now) > 0
+}
+
+func (i ScheduledInstalment) DaysOverdue(now time.Time) int {
+	return max(daysOverdue(i.DueDate, now), 0)
+}
+
+// DepositAmount is how much the client has to pay before the work starts.
+func (o *Order) DepositAmount() float64 {
+	if o.PaymentPlan == nil {
+		return 0
+	}
+	if o.PaymentPlan.DepositIsPercentage {
+		return o.TotalPrice() * min(o.PaymentPlan.Deposit, 100) / 100
+	}
+	return min(o.PaymentPlan.Deposit, o.TotalPrice())
+}
+
+func (o *Order) DepositReceived() bool {
+	return o.Paid() >= o.DepositAmount()
+}
+
+func (o *Order) ScheduledInstalments() []ScheduledInstalment {
+	if o.PaymentPlan == nil {
+		return nil
+	}
+
+	instalments := slices.Clone(o.PaymentPlan.Instalments)
+	slices.SortStableFunc(instalments, func(a, b Instalment) int {
+		return a.DueDate.Compare(b.DueDate)
+	})
+
+	available := max(o.Paid()-o.DepositAmount(), 0)
+	scheduled := make([]ScheduledInstalment, len(instalments))
+	for i, instalment := range instalments {
+		paid := min(available, instalment.Amount)
+		available -= paid
+		scheduled[i] = ScheduledInstalment{Instalment: instalment, Paid: paid}
+	}
+	return scheduled
+}
+
+// fitsOrder checks the plan isn't more than the order's total.
+func (p *PaymentPlan) fitsOrder(ord *Order) error {
+	if p.DepositIsPercentage && p.Deposit > 100 {
+		return ErrPlanOverTotal
+	}
+
+	planned := p.Deposit
+	if p.DepositIsPercentage {
+		planned = ord.TotalPrice() * p.Deposit / 100
+	}
+	for _, instalment := range p.Instalments {
+		planned += instalment.Amount
+	}
+
+	// Allowing for the cents lost in rounding the percentage.
+	if planned-ord.TotalPrice() > 0.01 {
+		return ErrPlanOverTotal
+	}
+	return nil
+}
+
+// Receivable is an order's overdue instalment.
+type Receivable struct {
+	Order      *Order
+	Instalment ScheduledInstalment
+}
+
+// OverdueInstalments lists the overdue instalments of the orders, the most
+// overdue first.
+func OverdueInstalments(orders []Order, now time.Time) []Receivable {
+	receivables := []Receivable{}
+	for i := range orders {
+		if orders[i].Status == StatusCanceled || orders[i].Status == StatusExpired {
+			continue
+		}
+		for _, instalment := range orders[i].ScheduledInstalments() {
+			if instalment.IsOverdue(now) {
+				receivables = append(receivables, Receivable{Order: &orders[i], Instalment: instalment})
+			}
+		}
+	}
+
+	slices.SortStableFunc(receivables, func(a, b Receivable) int {
+		return a.Instalment.DueDate.Compare(b.Instalment.DueDate)
+	})
+	return receivables
+}
+
+func (p *PaymentPlan) isEmpty() bool {
+	return p.Deposit == 0 && len(p.Instalments) == 0
+}
+
+func depositReceived(ord *Order) string {
+	if ord.PaymentPlan == nil || ord.DepositReceived() || ord.PaymentPlan.DepositWaivedBy != "" {
+		return ""
+	}
+	return fmt.Sprintf("the deposit of %.2f must be received first", ord.DepositAmount())
+}
+
+// daysOverdue is how many calendar days the date is before now.
+func daysOverdue(due, now time.Time) int {
+	due = time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+	now = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return int(math.Round(now.Sub(due).Hours() / 24))
+}
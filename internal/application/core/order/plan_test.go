@@ -0,0 +1,163 @@
+package order
+
+import (
+	"testing"
+	"time"
+
+	"github.com/omareloui/odinls/internal/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+// planOrder is an order of a 1000 total with the payment plan.
+func planOrder(plan *PaymentPlan, payments ...float64) Order {
+	ord := Order{
+		Status:      StatusConfirmed,
+		Items:       []Item{{Snapshot: ItemSnapshot{Price: 500}, Quantity: 2}},
+		PaymentPlan: plan,
+	}
+	for _, amount := range payments {
+		ord.Payments = append(ord.Payments, Payment{Amount: amount})
+	}
+	return ord
+}
+
+func TestDepositAmount(t *testing.T) {
+	tests := []struct {
+		name string
+		plan *PaymentPlan
+		want float64
+	}{
+		{"no plan", nil, 0},
+		{"amount", &PaymentPlan{Deposit: 300}, 300},
+		{"amount over the total", &PaymentPlan{Deposit: 1500}, 1000},
+		{"percentage", &PaymentPlan{Deposit: 30, DepositIsPercentage: true}, 300},
+		{"percentage over a hundred", &PaymentPlan{Deposit: 150, DepositIsPercentage: true}, 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ord := planOrder(tt.plan)
+			assert.Equal(t, tt.want, ord.DepositAmount())
+		})
+	}
+}
+
+func TestScheduledInstalments(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, time.June, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	// Out of their due dates' order to check the earliest is paid first.
+	plan := &PaymentPlan{
+		Deposit: 200,
+		Instalments: []Instalment{
+			{Amount: 400, DueDate: day(20)},
+			{Amount: 400, DueDate: day(10)},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		payments []float64
+		wantPaid []float64
+	}{
+		{"nothing paid", nil, []float64{0, 0}},
+		{"only the deposit paid", []float64{200}, []float64{0, 0}},
+		{"part of the first instalment", []float64{200, 150}, []float64{150, 0}},
+		{"over the first instalment", []float64{200, 500}, []float64{400, 100}},
+		{"refund is taken out of the latest", []float64{1000, -300}, []float64{400, 100}},
+		{"all paid", []float64{1000}, []float64{400, 400}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ord := planOrder(plan, tt.payments...)
+			scheduled := ord.ScheduledInstalments()
+			if assert.Len(t, scheduled, 2) {
+				assert.Equal(t, day(10), scheduled[0].DueDate)
+				assert.Equal(t, tt.wantPaid, []float64{scheduled[0].Paid, scheduled[1].Paid})
+			}
+		})
+	}
+}
+
+func TestPaymentPlanFitsOrder(t *testing.T) {
+	due := time.Date(2024, time.June, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		plan    PaymentPlan
+		wantErr bool
+	}{
+		{"deposit only", PaymentPlan{Deposit: 1000}, false},
+		{"deposit and instalments", PaymentPlan{Deposit: 200, Instalments: []Instalment{{Amount: 800, DueDate: due}}}, false},
+		{"under the total", PaymentPlan{Deposit: 200, Instalments: []Instalment{{Amount: 300, DueDate: due}}}, false},
+		{"over the total", PaymentPlan{Deposit: 200, Instalments: []Instalment{{Amount: 900, DueDate: due}}}, true},
+		{"percentage and instalments", PaymentPlan{Deposit: 25, DepositIsPercentage: true, Instalments: []Instalment{{Amount: 750, DueDate: due}}}, false},
+		{"percentage over a hundred", PaymentPlan{Deposit: 101, DepositIsPercentage: true}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ord := planOrder(nil)
+			err := tt.plan.fitsOrder(&ord)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errs.ErrInvalidPayment)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestStartingNeedsTheDeposit(t *testing.T) {
+	tests := []struct {
+		name     string
+		plan     *PaymentPlan
+		payments []float64
+		wantErr  bool
+	}{
+		{"no plan", nil, nil, false},
+		{"deposit not received", &PaymentPlan{Deposit: 300}, []float64{200}, true},
+		{"deposit received", &PaymentPlan{Deposit: 300}, []float64{200, 100}, false},
+		{"deposit refunded", &PaymentPlan{Deposit: 300}, []float64{300, -100}, true},
+		{"deposit waived", &PaymentPlan{Deposit: 300, DepositWaivedBy: "665dbe5ac352603c7e73fa5e"}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ord := planOrder(tt.plan, tt.payments...)
+			err := ord.TransitionTo(StatusInProgress, time.Now())
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errs.ErrInvalidTransition)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestOverdueInstalments(t *testing.T) {
+	now := time.Date(2024, time.June, 15, 18, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time {
+		return time.Date(2024, time.June, d, 9, 0, 0, 0, time.UTC)
+	}
+
+	plan := &PaymentPlan{Instalments: []Instalment{
+		{Amount: 300, DueDate: day(14)},
+		{Amount: 300, DueDate: day(15)},
+		{Amount: 400, DueDate: day(5)},
+	}}
+
+	paid := planOrder(plan, 1000)
+	canceled := planOrder(plan)
+	canceled.Status = StatusCanceled
+	unpaid := planOrder(plan, 500)
+
+	receivables := OverdueInstalments([]Order{paid, canceled, unpaid}, now)
+	if assert.Len(t, receivables, 1) {
+		assert.Equal(t, day(14), receivables[0].Instalment.DueDate)
+		assert.Equal(t, 200.0, receivables[0].Instalment.Outstanding())
+		assert.Equal(t, 1, receivables[0].Instalment.DaysOverdue(now))
+	}
+}
@@ -2,7 +2,23 @@ package order
 
 type OrderRepository interface {
 	GetOrders(opts ...RetrieveOptsFunc) ([]Order, error)
+	GetOrdersByStatuses(statuses []StatusEnum, opts ...RetrieveOptsFunc) ([]Order, error)
+	GetOrdersWithInstalments(opts ...RetrieveOptsFunc) ([]Order, error)
+	GetOrdersByClientID(clientID string, opts ...RetrieveOptsFunc) ([]Order, error)
 	GetOrderByID(id string, opts ...RetrieveOptsFunc) (*Order, error)
+	GetOrderByRef(ref string, opts ...RetrieveOptsFunc) (*Order, error)
 	CreateOrder(ord *Order, opts ...RetrieveOptsFunc) (*Order, error)
+	DeleteOrderByID(id string) error
 	UpdateOrderByID(id string, ord *Order, opts ...RetrieveOptsFunc) (*Order, error)
+	UpdateOrderStatusByID(id string, status StatusEnum, timeline Timeline, opts ...RetrieveOptsFunc) (*Order, error)
+	UpdateOrderStatusWaivingDeposit(id string, status StatusEnum, timeline Timeline, userID string, opts ...RetrieveOptsFunc) (*Order, error)
+	UpdateOrderStatusUnwaivingDeposit(id string, status StatusEnum, timeline Timeline, opts ...RetrieveOptsFunc) (*Order, error)
+	UpdateOrderItemProgress(orderID, itemID string, progress ItemProgressEnum, opts ...RetrieveOptsFunc) (*Order, error)
+	UpdateOrderItemCraftsman(orderID, itemID, craftsmanID string, opts ...RetrieveOptsFunc) (*Order, error)
+	UpdateOrderItemHides(orderID, itemID string, hideIDs []string, opts ...RetrieveOptsFunc) (*Order, error)
+	UpdateOrderItemPromotedProduct(orderID, itemID, productID string, opts ...RetrieveOptsFunc) (*Order, error)
+	SetOrderPaymentPlan(orderID string, plan *PaymentPlan, opts ...RetrieveOptsFunc) (*Order, error)
+	// AddOrderPayment adds the payment only if the order still has the given
+	// number of payments, otherwise it fails with errs.ErrDocumentNotFound.
+	AddOrderPayment(orderID string, payment *Payment, paymentsCount int, opts ...RetrieveOptsFunc) (*Order, error)
 }
@@ -4,7 +4,26 @@ import jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
 
 type OrderService interface {
 	GetOrders(claims *jwtadapter.AccessClaims, opts ...RetrieveOptsFunc) ([]Order, error)
+	GetClientHistory(claims *jwtadapter.AccessClaims, clientID string) (*ClientHistory, error)
+	GetClientsLifetimeValues(claims *jwtadapter.AccessClaims) (map[string]float64, error)
 	GetOrderByID(claims *jwtadapter.AccessClaims, id string, opts ...RetrieveOptsFunc) (*Order, error)
+	GetMyOrders(claims *jwtadapter.AccessClaims) ([]Order, error)
+	GetMyOrder(claims *jwtadapter.AccessClaims, id string, opts ...RetrieveOptsFunc) (*Order, error)
+	GetOrderByRef(claims *jwtadapter.AccessClaims, ref string, opts ...RetrieveOptsFunc) (*Order, error)
+	GetOrderTrackingByRef(ref string) (*Tracking, error)
 	CreateOrder(claims *jwtadapter.AccessClaims, ord *Order, opts ...RetrieveOptsFunc) (*Order, error)
-	UpdateOrderByID(claims *jwtadapter.AccessClaims, id string, ord *Order, opts ...RetrieveOptsFunc) (*Order, error)
+	CreateQuotedOrder(claims *jwtadapter.AccessClaims, ord *Order, claim func(orderID string) error, opts ...RetrieveOptsFunc) (*Order, error)
+	PriceItems(claims *jwtadapter.AccessClaims, clientID string, items []Item) error
+	PromoteCustomItem(claims *jwtadapter.AccessClaims, orderID, itemID string, opts ...RetrieveOptsFunc) (*Order, error)
+	UpdateOrderByID(claims *jwtadapter.AccessClaims, id string, ord *Order, refreshPrices bool, opts ...RetrieveOptsFunc) (*Order, error)
+	TransitionOrderStatus(claims *jwtadapter.AccessClaims, id string, to StatusEnum, opts ...RetrieveOptsFunc) (*Order, error)
+	StartOrderWaivingDeposit(claims *jwtadapter.AccessClaims, id string, opts ...RetrieveOptsFunc) (*Order, error)
+	RecordPayment(claims *jwtadapter.AccessClaims, orderID string, payment *Payment, opts ...RetrieveOptsFunc) (*Order, error)
+	RecordRefund(claims *jwtadapter.AccessClaims, orderID string, refund *Payment, opts ...RetrieveOptsFunc) (*Order, error)
+	SetPaymentPlan(claims *jwtadapter.AccessClaims, orderID string, plan *PaymentPlan, opts ...RetrieveOptsFunc) (*Order, error)
+	GetReceivables(claims *jwtadapter.AccessClaims) ([]Receivable, error)
+	GetBoard(claims *jwtadapter.AccessClaims) (*Board, error)
+	UpdateItemProgress(claims *jwtadapter.AccessClaims, orderID, itemID string, progress ItemProgressEnum, opts ...RetrieveOptsFunc) (*Order, error)
+	AssignItemCraftsman(claims *jwtadapter.AccessClaims, orderID, itemID, craftsmanID string, opts ...RetrieveOptsFunc) (*Order, error)
+	PickItemHides(claims *jwtadapter.AccessClaims, orderID, itemID string, hideIDs []string, opts ...RetrieveOptsFunc) (*Order, error)
 }
@@ -0,0 +1,101 @@
+package order
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/omareloui/odinls/internal/errs"
+)
+
+type statusGuard func(ord *Order) string
+
+// statusTransitions is the order lifecycle. Any move that isn't listed here is
+// rejected, the completed, canceled, and expired statuses are final.
+var statusTransitions = map[StatusEnum][]StatusEnum{
+	StatusPendingConfirmation: {StatusConfirmed, StatusCanceled, StatusExpired},
+	StatusConfirmed:           {StatusInProgress, StatusCanceled},
+	StatusInProgress:          {StatusPendingShipment, StatusCanceled},
+	StatusPendingShipment:     {StatusShipping, StatusCompleted, StatusCanceled},
+	StatusShipping:            {StatusCompleted},
+	StatusCompleted:           {},
+	StatusCanceled:            {},
+	StatusExpired:             {},
+}
+
+var statusGuards = map[StatusEnum][]statusGuard{
+	StatusInProgress:      {depositReceived},
+	StatusPendingShipment: {allItemsDone},
+}
+
+type StatusTransitionError struct {
+	From   StatusEnum
+	To     StatusEnum
+	Reason string
+}
+
+func (e *StatusTransitionError) Error() string {
+	msg := fmt.Sprintf("can't move the order from %q to %q", e.From.View(), e.To.View())
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+func (e *StatusTransitionError) Unwrap() error {
+	return errs.ErrInvalidTransition
+}
+
+func (s StatusEnum) NextStatuses() []StatusEnum {
+	return statusTransitions[s]
+}
+
+func (s StatusEnum) CanMoveTo(to StatusEnum) bool {
+	return slices.Contains(statusTransitions[s], to)
+}
+
+func (s StatusEnum) IsFinal() bool {
+	next, ok := statusTransitions[s]
+	return ok && len(next) == 0
+}
+
+// TransitionTo moves the order to the given status if the move is allowed and
+// stamps the matching timeline field.
+func (o *Order) TransitionTo(to StatusEnum, at time.Time) error {
+	from := o.Status
+	if from == "" {
+		from = StatusPendingConfirmation
+	}
+
+	if !from.CanMoveTo(to) {
+		return &StatusTransitionError{From: from, To: to}
+	}
+
+	for _, guard := range statusGuards[to] {
+		if reason := guard(o); reason != "" {
+			return &StatusTransitionError{From: from, To: to, Reason: reason}
+		}
+	}
+
+	o.Status = to
+
+	switch to {
+	case StatusPendingShipment:
+		o.Timeline.DoneOn = at
+	case StatusShipping:
+		o.Timeline.ShippedOn = at
+	case StatusCompleted, StatusCanceled, StatusExpired:
+		o.Timeline.ResolvedOn = at
+	}
+
+	return nil
+}
+
+func allItemsDone(ord *Order) string {
+	for _, item := range ord.Items {
+		if item.Progress != ItemProgressDone {
+			return "all the items must be done first"
+		}
+	}
+	return ""
+}
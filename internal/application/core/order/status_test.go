@@ -0,0 +1,84 @@
+package order
+
+import (
+	"testing"
+	"time"
+
+	"github.com/omareloui/odinls/internal/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransitionTo(t *testing.T) {
+	at := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+
+	doneItems := []Item{{Progress: ItemProgressDone}, {Progress: ItemProgressDone}}
+	startedItems := []Item{{Progress: ItemProgressDone}, {Progress: ItemProgressCrafting}}
+
+	tests := []struct {
+		name    string
+		ord     Order
+		to      StatusEnum
+		wantErr bool
+	}{
+		{"empty status is pending confirmation", Order{}, StatusConfirmed, false},
+		{"confirm", Order{Status: StatusPendingConfirmation}, StatusConfirmed, false},
+		{"expire", Order{Status: StatusPendingConfirmation}, StatusExpired, false},
+		{"can't skip confirming", Order{Status: StatusPendingConfirmation}, StatusInProgress, true},
+		{"start", Order{Status: StatusConfirmed}, StatusInProgress, false},
+		{"can't go back", Order{Status: StatusInProgress}, StatusConfirmed, true},
+		{"done with all the items done", Order{Status: StatusInProgress, Items: doneItems}, StatusPendingShipment, false},
+		{"not done with an item in progress", Order{Status: StatusInProgress, Items: startedItems}, StatusPendingShipment, true},
+		{"ship", Order{Status: StatusPendingShipment}, StatusShipping, false},
+		{"complete without shipping", Order{Status: StatusPendingShipment}, StatusCompleted, false},
+		{"can't cancel a shipping order", Order{Status: StatusShipping}, StatusCanceled, true},
+		{"completed is final", Order{Status: StatusCompleted}, StatusCanceled, true},
+		{"canceled is final", Order{Status: StatusCanceled}, StatusConfirmed, true},
+		{"expired is final", Order{Status: StatusExpired}, StatusConfirmed, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from := tt.ord.Status
+			err := tt.ord.TransitionTo(tt.to, at)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errs.ErrInvalidTransition)
+				assert.Equal(t, from, tt.ord.Status)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.to, tt.ord.Status)
+		})
+	}
+}
+
+func TestTransitionToTimeline(t *testing.T) {
+	at := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		from  StatusEnum
+		to    StatusEnum
+		field func(tl Timeline) time.Time
+	}{
+		{"done on", StatusInProgress, StatusPendingShipment, func(tl Timeline) time.Time { return tl.DoneOn }},
+		{"shipped on", StatusPendingShipment, StatusShipping, func(tl Timeline) time.Time { return tl.ShippedOn }},
+		{"resolved on completing", StatusShipping, StatusCompleted, func(tl Timeline) time.Time { return tl.ResolvedOn }},
+		{"resolved on canceling", StatusConfirmed, StatusCanceled, func(tl Timeline) time.Time { return tl.ResolvedOn }},
+		{"resolved on expiring", StatusPendingConfirmation, StatusExpired, func(tl Timeline) time.Time { return tl.ResolvedOn }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ord := Order{Status: tt.from}
+			assert.NoError(t, ord.TransitionTo(tt.to, at))
+			assert.Equal(t, at, tt.field(ord.Timeline))
+		})
+	}
+}
+
+func TestIsFinal(t *testing.T) {
+	for _, status := range StatusesEnums() {
+		want := status == StatusCompleted || status == StatusCanceled || status == StatusExpired
+		assert.Equal(t, want, status.IsFinal(), status)
+	}
+}
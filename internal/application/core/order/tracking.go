@@ -0,0 +1,56 @@
+package order
+
+import (
+	"strings"
+	"time"
+)
+
+// Tracking is the public view of an order, it's what the customer sees using
+// the order's ref so it mustn't include any internal data.
+type Tracking struct {
+	Ref    string
+	Status StatusEnum
+	Items  []TrackingItem
+
+	IssuanceDate  time.Time
+	ScheduledDate time.Time
+	DueDate       time.Time
+	ShippedOn     time.Time
+
+	RemainingAmount float64
+}
+
+type TrackingItem struct {
+	Name        string
+	VariantName string
+	Quantity    uint16
+	Progress    ItemProgressEnum
+}
+
+func (o *Order) Tracking() *Tracking {
+	t := &Tracking{
+		Ref:             o.RefView(),
+		Status:          o.Status,
+		IssuanceDate:    o.Timeline.IssuanceDate,
+		ScheduledDate:   o.Timeline.ScheduledDate,
+		DueDate:         o.Timeline.DueDate,
+		ShippedOn:       o.Timeline.ShippedOn,
+		RemainingAmount: o.RemainingAmount(),
+	}
+	for _, item := range o.Items {
+		t.Items = append(t.Items, TrackingItem{
+			Name:        item.Snapshot.ProductName,
+			VariantName: item.Snapshot.VariantName,
+			Quantity:    item.Quantity,
+			Progress:    item.Progress,
+		})
+	}
+	return t
+}
+
+// NormalizeRef accepts the ref as it's shown to the customer (e.g.
+// "ab12-cd34") and returns it as it's stored.
+func NormalizeRef(ref string) string {
+	ref = strings.ToUpper(strings.TrimSpace(ref))
+	return strings.ReplaceAll(ref, "-", "")
+}
@@ -0,0 +1,145 @@
+package product
+
+import (
+	"math"
+	"slices"
+
+	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+	"github.com/omareloui/odinls/internal/application/core/material"
+	"github.com/omareloui/odinls/internal/errs"
+)
+
+// Capacity is how many of a variant can be made with the available
+// materials.
+type Capacity struct {
+	ProductID   string
+	ProductName string
+	VariantID   string
+	VariantName string
+	SKU         string
+
+	// Buildable is math.MaxInt for the variants that aren't material bound.
+	Buildable int
+	// MaterialBound is false if the variant doesn't use any material, so
+	// there's no limit to how many of it can be made.
+	MaterialBound bool
+	// LimitingMaterial is the material that runs out first, it's nil if the
+	// variant isn't material bound.
+	LimitingMaterial *material.Material
+}
+
+// Wish is a quantity of a variant that's planned to be made.
+type Wish struct {
+	VariantID string
+	Quantity  int
+}
+
+// GetCapacities calculates how many of each variant can be made with the
+// available materials.
+func (s *productService) GetCapacities(claims *jwtadapter.AccessClaims) ([]Capacity, error) {
+	if claims == nil || !claims.Role.IsModerator() {
+		return nil, errs.ErrForbidden
+	}
+
+	prods, err := s.repo.GetProducts()
+	if err != nil {
+		return nil, err
+	}
+
+	materials, err := s.materialsByID(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	capacities := []Capacity{}
+	for _, prod := range prods {
+		for _, v := range prod.Variants {
+			v.ProductSKU = prod.SKU()
+			capacity := Capacity{
+				ProductID:   prod.ID,
+				ProductName: prod.Name,
+				VariantID:   v.ID,
+				VariantName: v.Name,
+				SKU:         v.SKU(),
+				Buildable:   math.MaxInt,
+			}
+
+			for _, usage := range v.MaterialUsage {
+				if usage.Quantity <= 0 {
+					continue
+				}
+				// A missing material, like a deleted one, has nothing available.
+				mat, ok := materials[usage.MaterialID]
+				if !ok {
+					mat = &material.Material{ID: usage.MaterialID, Name: usage.MaterialID}
+				}
+
+				buildable := int(math.Floor(math.Max(mat.Available(), 0) / usage.Quantity))
+				if !capacity.MaterialBound || buildable < capacity.Buildable {
+					capacity.Buildable = buildable
+					capacity.MaterialBound = true
+					capacity.LimitingMaterial = mat
+				}
+			}
+
+			capacities = append(capacities, capacity)
+		}
+	}
+
+	return capacities, nil
+}
+
+// GetShortfalls lists the materials that need to be purchased to make the
+// wished variants.
+func (s *productService) GetShortfalls(claims *jwtadapter.AccessClaims, wishes []Wish) ([]material.Shortage, error) {
+	if claims == nil || !claims.Role.IsModerator() {
+		return nil, errs.ErrForbidden
+	}
+
+	materials, err := s.materialsByID(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	required := map[string]float64{}
+	materialsIDs := []string{}
+	for _, wish := range wishes {
+		if wish.Quantity <= 0 {
+			continue
+		}
+
+		prod, err := s.repo.GetProductByVariantID(wish.VariantID)
+		if err != nil {
+			return nil, err
+		}
+
+		idx := slices.IndexFunc(prod.Variants, func(v Variant) bool {
+			return v.ID == wish.VariantID
+		})
+		if idx == -1 {
+			return nil, errs.ErrDocumentNotFound
+		}
+
+		for _, usage := range prod.Variants[idx].MaterialUsage {
+			if _, ok := required[usage.MaterialID]; !ok {
+				materialsIDs = append(materialsIDs, usage.MaterialID)
+			}
+			required[usage.MaterialID] += usage.Quantity * float64(wish.Quantity)
+		}
+	}
+
+	return material.FindShortages(materialsIDs, required, materials), nil
+}
+
+func (s *productService) materialsByID(claims *jwtadapter.AccessClaims) (map[string]*material.Material, error) {
+	mats, err := s.materialService.GetMaterials(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	materials := make(map[string]*material.Material, len(mats))
+	for i := range mats {
+		materials[mats[i].ID] = &mats[i]
+	}
+	return materials, nil
+}
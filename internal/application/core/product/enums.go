@@ -94,3 +94,27 @@ func CategoriesCodes() []string {
 	return categories
 }
 
+// PriceTierEnum is which of the variants' prices a client gets.
+type PriceTierEnum string
+
+const (
+	PriceTierRetail      PriceTierEnum = "RETAIL"
+	PriceTierWholesale   PriceTierEnum = "WHOLESALE"
+	PriceTierDistributor PriceTierEnum = "DISTRIBUTOR"
+)
+
+func (t PriceTierEnum) View() string {
+	v := map[PriceTierEnum]string{
+		PriceTierRetail:      "Retail",
+		PriceTierWholesale:   "Wholesale",
+		PriceTierDistributor: "Distributor",
+	}[t]
+	if v == "" {
+		return PriceTierRetail.View()
+	}
+	return v
+}
+
+func PriceTiersEnums() []PriceTierEnum {
+	return []PriceTierEnum{PriceTierRetail, PriceTierWholesale, PriceTierDistributor}
+}
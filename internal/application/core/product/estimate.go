@@ -0,0 +1,54 @@
+package product
+
+import jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+
+// Estimate is what a variant costs to make and its margin at its price, by
+// the costing settings when it got estimated.
+type Estimate struct {
+	MaterialCost float64 `json:"material_cost" bson:"material_cost"`
+	TimeCost     float64 `json:"time_cost" bson:"time_cost"`
+	FixedCost    float64 `json:"fixed_cost" bson:"fixed_cost"`
+	TotalCost    float64 `json:"total_cost" bson:"total_cost"`
+	// EstPrice is the retail price the settings give the variant.
+	EstPrice float64 `json:"est_price" bson:"est_price"`
+
+	Profit                float64 `json:"profit" bson:"profit"`
+	MaxDiscountPercentage float64 `json:"max_discount_percentage" bson:"max_discount_percentage"`
+}
+
+// EstimateVariant converts the quantities of the variant's used materials to
+// the materials' units and estimates it the same way the catalog variants
+// are priced. A variant without a price gets the estimated one.
+func (s *productService) EstimateVariant(claims *jwtadapter.AccessClaims, v *Variant) (*Estimate, error) {
+	pricer, err := s.newPricer(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pricer.Normalize(v); err != nil {
+		return nil, err
+	}
+
+	est, settings, err := pricer.estimationVariant(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.Price == 0 {
+		v.Price = est.EstPrice(settings)
+	}
+
+	estimate := &Estimate{
+		MaterialCost: est.MaterialCost(settings),
+		TimeCost:     est.TimeCost(settings),
+		FixedCost:    est.FixedCost(settings),
+		TotalCost:    est.TotalCost(settings),
+		EstPrice:     est.EstPrice(settings),
+		Profit:       est.Profit(settings, v.Price),
+	}
+	if v.Price > 0 {
+		estimate.MaxDiscountPercentage = est.MaxDiscountPercentage(settings, v.Price)
+	}
+
+	return estimate, nil
+}
@@ -2,9 +2,13 @@ package product
 
 import (
 	"slices"
+	"time"
 
 	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+	"github.com/omareloui/odinls/internal/application/core/costing"
 	"github.com/omareloui/odinls/internal/application/core/counter"
+	"github.com/omareloui/odinls/internal/application/core/material"
+	"github.com/omareloui/odinls/internal/application/core/user"
 	"github.com/omareloui/odinls/internal/errs"
 	"github.com/omareloui/odinls/internal/interfaces"
 )
@@ -14,14 +18,21 @@ type productService struct {
 	validator      interfaces.Validator
 	sanitizer      interfaces.Sanitizer
 	counterService counter.CounterService
+
+	costingService  costing.CostingService
+	materialService material.MaterialService
+	userService     user.UserService
 }
 
-func NewProductService(repo ProductRepository, validator interfaces.Validator, sanitizer interfaces.Sanitizer, counterService counter.CounterService) *productService {
+func NewProductService(repo ProductRepository, validator interfaces.Validator, sanitizer interfaces.Sanitizer, counterService counter.CounterService, costingService costing.CostingService, materialService material.MaterialService, userService user.UserService) *productService {
 	return &productService{
-		repo:           repo,
-		validator:      validator,
-		sanitizer:      sanitizer,
-		counterService: counterService,
+		repo:            repo,
+		validator:       validator,
+		sanitizer:       sanitizer,
+		counterService:  counterService,
+		costingService:  costingService,
+		materialService: materialService,
+		userService:     userService,
 	}
 }
 
@@ -58,16 +69,21 @@ func (s *productService) CreateProduct(claims *jwtadapter.AccessClaims, prod *Pr
 
 	prod.Number = num
 
+	pricer, err := s.newPricer(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
 	for i := range prod.Variants {
 		prod.Variants[i].ProductSKU = prod.SKU()
-		if prod.Variants[i].Price == 0 {
-			// TODO: populate the used materials to set the price
-			prod.Variants[i].Price = prod.Variants[i].EstPrice()
+		if err := pricer.Normalize(&prod.Variants[i]); err != nil {
+			return nil, err
 		}
-		if prod.Variants[i].WholesalePrice == 0 {
-			// TODO: populate the used materials to set the price
-			prod.Variants[i].Price = prod.Variants[i].EstWholesalePrice()
+		if err := pricer.Price(&prod.Variants[i]); err != nil {
+			return nil, err
 		}
+		prod.Variants[i].RecordPrice("", now)
 	}
 
 	return s.repo.CreateProduct(prod, options...)
@@ -105,16 +121,29 @@ func (s *productService) UpdateProductByID(claims *jwtadapter.AccessClaims, id s
 	uprod.ID = id
 	uprod.CreatedAt = prod.CreatedAt
 
+	pricer, err := s.newPricer(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
 	for i := range uprod.Variants {
-		prod.Variants[i].ProductSKU = prod.SKU()
-		if prod.Variants[i].Price == 0 {
-			// TODO: populate the used materials to set the price
-			prod.Variants[i].Price = prod.Variants[i].EstPrice()
+		uprod.Variants[i].ProductSKU = uprod.SKU()
+		if err := pricer.Normalize(&uprod.Variants[i]); err != nil {
+			return nil, err
 		}
-		if prod.Variants[i].WholesalePrice == 0 {
-			// TODO: populate the used materials to set the price
-			prod.Variants[i].Price = prod.Variants[i].EstWholesalePrice()
+		if err := pricer.Price(&uprod.Variants[i]); err != nil {
+			return nil, err
+		}
+
+		idx := slices.IndexFunc(prod.Variants, func(variant Variant) bool {
+			return variant.ID == uprod.Variants[i].ID
+		})
+		if idx != -1 {
+			uprod.Variants[i].PriceHistory = prod.Variants[idx].PriceHistory
+			uprod.Variants[i].TierPrices = prod.Variants[idx].TierPrices
 		}
+		uprod.Variants[i].RecordPrice("", now)
 	}
 
 	// This keeps the variant even if the new update data doesn't
@@ -5,21 +5,12 @@ import (
 	"math"
 	"time"
 
+	"github.com/omareloui/odinls/internal/application/core/costing"
 	"github.com/omareloui/odinls/internal/application/core/material"
 )
 
-const (
-	hourlyRate = 60
-
-	monthlyFixedCosts = 6000
-	monthlyWorkHours  = 176
-	hourlyFixedCosts  = monthlyFixedCosts / monthlyWorkHours
-
-	incalculableCostsPercentage = 0.05
-
-	retailProfitPercentage    = 1
-	wholesaleProfitPercentage = 0.5
-)
+// priceRoundingStep is what the estimated prices are rounded down to.
+const priceRoundingStep = 5
 
 type Product struct {
 	ID     string `json:"id" bson:"_id,omitempty"`
@@ -42,10 +33,27 @@ func (p *Product) SKU() string {
 type MaterialUsage struct {
 	MaterialID string  `json:"material_id" bson:"material_id"`
 	Quantity   float64 `json:"quantity" bson:"quantity"`
+	// Unit is the unit the quantity was entered in, it's normalized to the
+	// material's unit before the variant is saved.
+	Unit material.Unit `json:"unit" bson:"unit,omitempty"`
 
 	Material *material.Material `json:"material" bson:"populated_material"`
 }
 
+// UsageError is an error with one of the variant's used materials.
+type UsageError struct {
+	Index int
+	Err   error
+}
+
+func (e *UsageError) Error() string {
+	return fmt.Sprintf("material #%d: %s", e.Index+1, e.Err)
+}
+
+func (e *UsageError) Unwrap() error {
+	return e.Err
+}
+
 type Variant struct {
 	ID          string `json:"id" bson:"_id,omitempty"`
 	Suffix      string `json:"suffix" bson:"suffix,omitempty" conform:"trim,lower" validate:"required,min=2,max=255"`
@@ -59,55 +67,102 @@ type Variant struct {
 
 	Price          float64 `json:"price" bson:"price"`
 	WholesalePrice float64 `json:"wholesale_price" bson:"wholesale_price"`
+	// TierPrices are the distributors' prices and the quantity breaks of
+	// every tier.
+	TierPrices []TierPrice `json:"tier_prices" bson:"tier_prices,omitempty" validate:"dive"`
+
+	// AutoPriced variants get their prices recalculated when the price of a
+	// material they use changes, the rest are listed for review instead.
+	AutoPriced   bool          `json:"auto_priced" bson:"auto_priced"`
+	PriceHistory []PriceChange `json:"price_history" bson:"price_history,omitempty"`
 
 	TimeToCraft time.Duration `json:"time_to_craft" bson:"time_to_craft,omitempty"`
-	ProductSKU  string        `json:"-" bson:"-"`
+
+	// DefaultCraftsmanID is the one who usually makes this variant, their
+	// hourly rate is used to price it.
+	DefaultCraftsmanID string `json:"default_craftsman_id" bson:"default_craftsman,omitempty" validate:"omitempty,mongodb"`
+
+	ProductSKU string `json:"-" bson:"-"`
+}
+
+type PriceChange struct {
+	Price          float64   `json:"price" bson:"price"`
+	WholesalePrice float64   `json:"wholesale_price" bson:"wholesale_price"`
+	Reason         string    `json:"reason" bson:"reason,omitempty"`
+	Date           time.Time `json:"date" bson:"date"`
 }
 
 func (v *Variant) SKU() string {
 	return fmt.Sprintf("%s-%s", v.ProductSKU, v.Suffix)
 }
 
-func (v *Variant) MaterialCost() float64 {
+func (v *Variant) MaterialCost(settings *costing.Settings) float64 {
 	var sum float64 = 0
 	for _, u := range v.MaterialUsage {
 		if u.Material == nil {
 			return 0
 		}
-		sum += u.Quantity * u.Material.PricePerUnit
+		sum += u.Quantity * u.Material.UnitCost(settings.MaterialCostingMethod)
 	}
-	return sum * (1 + incalculableCostsPercentage)
+	return sum * (1 + settings.IncalculableCostsPercentage/100)
 }
 
-func (v *Variant) TimeCost() float64 {
-	return hourlyRate * v.TimeToCraft.Hours()
+func (v *Variant) TimeCost(settings *costing.Settings) float64 {
+	return settings.HourlyRate * v.TimeToCraft.Hours()
 }
 
-func (v *Variant) FixedCost() float64 {
-	return hourlyFixedCosts * v.TimeToCraft.Hours()
+func (v *Variant) FixedCost(settings *costing.Settings) float64 {
+	return settings.HourlyFixedCosts() * v.TimeToCraft.Hours()
 }
 
-func (v *Variant) TotalCost() float64 {
-	return v.TimeCost() + v.MaterialCost() + v.FixedCost()
+func (v *Variant) TotalCost(settings *costing.Settings) float64 {
+	return v.TimeCost(settings) + v.MaterialCost(settings) + v.FixedCost(settings)
 }
 
-func (v *Variant) EstPrice() float64 {
-	return v.estPrice(retailProfitPercentage)
+func (v *Variant) EstPrice(settings *costing.Settings) float64 {
+	return v.estPrice(settings, settings.RetailProfitPercentage)
 }
 
-func (v *Variant) EstWholesalePrice() float64 {
-	return v.estPrice(wholesaleProfitPercentage)
+func (v *Variant) EstWholesalePrice(settings *costing.Settings) float64 {
+	return v.estPrice(settings, settings.WholesaleProfitPercentage)
 }
 
-func (v *Variant) estPrice(profitPercentage float64) float64 {
-	return math.Floor((v.TotalCost()*(1+profitPercentage))/5) * 5
+func (v *Variant) estPrice(settings *costing.Settings, profitPercentage float64) float64 {
+	return math.Floor((v.TotalCost(settings)*(1+profitPercentage/100))/priceRoundingStep) * priceRoundingStep
 }
 
-func (v *Variant) Profit(price float64) float64 {
-	return price - v.TotalCost()
+func (v *Variant) Profit(settings *costing.Settings, price float64) float64 {
+	return price - v.TotalCost(settings)
 }
 
-func (v *Variant) MaxDiscountPercentage(price float64) float64 {
-	profit := v.Profit(price)
+func (v *Variant) MaxDiscountPercentage(settings *costing.Settings, price float64) float64 {
+	profit := v.Profit(settings, price)
 	return profit / price * 100
 }
+
+// UsesMaterial reports whether the variant is made with the material.
+func (v *Variant) UsesMaterial(materialID string) bool {
+	for _, u := range v.MaterialUsage {
+		if u.MaterialID == materialID {
+			return true
+		}
+	}
+	return false
+}
+
+// RecordPrice appends the variant's current prices to its history if they
+// changed since the last entry.
+func (v *Variant) RecordPrice(reason string, at time.Time) {
+	if n := len(v.PriceHistory); n > 0 {
+		last := v.PriceHistory[n-1]
+		if last.Price == v.Price && last.WholesalePrice == v.WholesalePrice {
+			return
+		}
+	}
+	v.PriceHistory = append(v.PriceHistory, PriceChange{
+		Price:          v.Price,
+		WholesalePrice: v.WholesalePrice,
+		Reason:         reason,
+		Date:           at,
+	})
+}
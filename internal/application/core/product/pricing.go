@@ -0,0 +1,158 @@
+package product
+
+import (
+	"slices"
+
+	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+	"github.com/omareloui/odinls/internal/application/core/costing"
+	"github.com/omareloui/odinls/internal/application/core/material"
+)
+
+// pricer fills the variants' missing prices from their used materials and the
+// costing settings. It caches the materials and the craftsmen's rates so each
+// is fetched once per product.
+type pricer struct {
+	claims   *jwtadapter.AccessClaims
+	service  *productService
+	settings *costing.Settings
+
+	materials map[string]*material.Material
+	rates     map[string]float64
+}
+
+func (s *productService) newPricer(claims *jwtadapter.AccessClaims) (*pricer, error) {
+	settings, err := s.costingService.GetSettings(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pricer{
+		claims:    claims,
+		service:   s,
+		settings:  settings,
+		materials: map[string]*material.Material{},
+		rates:     map[string]float64{},
+	}, nil
+}
+
+// Price sets the variant's retail and wholesale prices if they're not set.
+func (p *pricer) Price(v *Variant) error {
+	if v.Price != 0 && v.WholesalePrice != 0 {
+		return nil
+	}
+
+	est, settings, err := p.estimationVariant(v)
+	if err != nil {
+		return err
+	}
+
+	if v.Price == 0 {
+		v.Price = est.EstPrice(settings)
+	}
+	if v.WholesalePrice == 0 {
+		v.WholesalePrice = est.EstWholesalePrice(settings)
+	}
+
+	return nil
+}
+
+// Reprice recalculates both of the variant's prices.
+func (p *pricer) Reprice(v *Variant) error {
+	est, settings, err := p.estimationVariant(v)
+	if err != nil {
+		return err
+	}
+
+	v.Price = est.EstPrice(settings)
+	v.WholesalePrice = est.EstWholesalePrice(settings)
+	return nil
+}
+
+// estimationVariant returns a copy of the variant with its materials
+// populated, so they don't get saved with it, and the settings to price it
+// with.
+func (p *pricer) estimationVariant(v *Variant) (*Variant, *costing.Settings, error) {
+	est := *v
+	est.MaterialUsage = slices.Clone(v.MaterialUsage)
+	if err := p.populateMaterials(&est); err != nil {
+		return nil, nil, err
+	}
+
+	settings, err := p.variantSettings(v)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &est, settings, nil
+}
+
+// Normalize converts the quantities of the variant's used materials to the
+// materials' units.
+func (p *pricer) Normalize(v *Variant) error {
+	for i, usage := range v.MaterialUsage {
+		mat, err := p.material(usage.MaterialID)
+		if err != nil {
+			return err
+		}
+
+		quantity, err := mat.Normalize(usage.Quantity, usage.Unit)
+		if err != nil {
+			return &UsageError{Index: i, Err: err}
+		}
+
+		v.MaterialUsage[i].Quantity = quantity
+		v.MaterialUsage[i].Unit = mat.Unit
+	}
+	return nil
+}
+
+func (p *pricer) populateMaterials(v *Variant) error {
+	for i, usage := range v.MaterialUsage {
+		if usage.Material != nil {
+			continue
+		}
+
+		mat, err := p.material(usage.MaterialID)
+		if err != nil {
+			return err
+		}
+
+		v.MaterialUsage[i].Material = mat
+	}
+	return nil
+}
+
+func (p *pricer) material(id string) (*material.Material, error) {
+	if mat, ok := p.materials[id]; ok {
+		return mat, nil
+	}
+
+	mat, err := p.service.materialService.GetMaterialByID(p.claims, id)
+	if err != nil {
+		return nil, err
+	}
+	p.materials[id] = mat
+	return mat, nil
+}
+
+// variantSettings returns the settings with the hourly rate of the variant's
+// default craftsman if it has one.
+func (p *pricer) variantSettings(v *Variant) (*costing.Settings, error) {
+	if v.DefaultCraftsmanID == "" {
+		return p.settings, nil
+	}
+
+	rate, ok := p.rates[v.DefaultCraftsmanID]
+	if !ok {
+		usr, err := p.service.userService.GetUserByID(v.DefaultCraftsmanID)
+		if err != nil {
+			return nil, err
+		}
+		if usr.IsCraftsman() {
+			rate = usr.Craftsman.HourlyRate
+		}
+		p.rates[v.DefaultCraftsmanID] = rate
+	}
+
+	return p.settings.WithHourlyRate(rate), nil
+}
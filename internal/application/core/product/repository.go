@@ -4,6 +4,7 @@ type ProductRepository interface {
 	GetProducts(opts ...RetrieveOptsFunc) ([]Product, error)
 	GetProductByID(id string, opts ...RetrieveOptsFunc) (*Product, error)
 	GetProductByVariantID(id string, opts ...RetrieveOptsFunc) (*Product, error)
+	GetProductsByMaterialID(materialID string, opts ...RetrieveOptsFunc) ([]Product, error)
 	CreateProduct(prod *Product, opts ...RetrieveOptsFunc) (*Product, error)
 	UpdateProductByID(id string, prod *Product, opts ...RetrieveOptsFunc) (*Product, error)
 }
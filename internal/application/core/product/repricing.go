@@ -0,0 +1,149 @@
+package product
+
+import (
+	"time"
+
+	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+	"github.com/omareloui/odinls/internal/errs"
+)
+
+const materialPriceChangeReason = "Material price change"
+
+// PriceReview is a variant whose margin fell below the minimum in the costing
+// settings.
+type PriceReview struct {
+	ProductID   string
+	ProductName string
+	VariantID   string
+	VariantName string
+	SKU         string
+
+	Price            float64
+	TotalCost        float64
+	MarginPercentage float64
+}
+
+type RepricingResult struct {
+	Repriced []Variant
+	Reviews  []PriceReview
+}
+
+// RepriceByMaterial reprices the auto priced variants that use the material,
+// and lists the rest of them for review if their margin got too low.
+func (s *productService) RepriceByMaterial(claims *jwtadapter.AccessClaims, materialID string) (*RepricingResult, error) {
+	if claims == nil || !claims.Role.IsAdmin() {
+		return nil, errs.ErrForbidden
+	}
+
+	prods, err := s.repo.GetProductsByMaterialID(materialID)
+	if err != nil {
+		return nil, err
+	}
+
+	pricer, err := s.newPricer(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := &RepricingResult{Repriced: []Variant{}, Reviews: []PriceReview{}}
+
+	for _, prod := range prods {
+		repriced := false
+
+		for i := range prod.Variants {
+			v := &prod.Variants[i]
+			if !v.UsesMaterial(materialID) {
+				continue
+			}
+
+			if v.AutoPriced {
+				if err := pricer.Reprice(v); err != nil {
+					return nil, err
+				}
+				v.RecordPrice(materialPriceChangeReason, now)
+				result.Repriced = append(result.Repriced, *v)
+				repriced = true
+				continue
+			}
+
+			review, err := pricer.Review(&prod, v)
+			if err != nil {
+				return nil, err
+			}
+			if review != nil {
+				result.Reviews = append(result.Reviews, *review)
+			}
+		}
+
+		if repriced {
+			if _, err := s.repo.UpdateProductByID(prod.ID, &prod); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// GetPriceReviews lists all the variants with a margin below the minimum.
+func (s *productService) GetPriceReviews(claims *jwtadapter.AccessClaims) ([]PriceReview, error) {
+	if claims == nil || !claims.Role.IsAdmin() {
+		return nil, errs.ErrForbidden
+	}
+
+	prods, err := s.repo.GetProducts()
+	if err != nil {
+		return nil, err
+	}
+
+	pricer, err := s.newPricer(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := []PriceReview{}
+	for _, prod := range prods {
+		for i := range prod.Variants {
+			review, err := pricer.Review(&prod, &prod.Variants[i])
+			if err != nil {
+				return nil, err
+			}
+			if review != nil {
+				reviews = append(reviews, *review)
+			}
+		}
+	}
+
+	return reviews, nil
+}
+
+// Review returns the variant's price review if its margin is below the
+// minimum, or nil if its price is fine.
+func (p *pricer) Review(prod *Product, v *Variant) (*PriceReview, error) {
+	if v.Price <= 0 {
+		return nil, nil
+	}
+
+	est, settings, err := p.estimationVariant(v)
+	if err != nil {
+		return nil, err
+	}
+
+	margin := est.MaxDiscountPercentage(settings, v.Price)
+	if margin >= settings.MinMarginPercentage {
+		return nil, nil
+	}
+
+	est.ProductSKU = prod.SKU()
+	return &PriceReview{
+		ProductID:        prod.ID,
+		ProductName:      prod.Name,
+		VariantID:        v.ID,
+		VariantName:      v.Name,
+		SKU:              est.SKU(),
+		Price:            v.Price,
+		TotalCost:        est.TotalCost(settings),
+		MarginPercentage: margin,
+	}, nil
+}
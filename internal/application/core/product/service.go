@@ -1,6 +1,9 @@
 package product
 
-import jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+import (
+	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+	"github.com/omareloui/odinls/internal/application/core/material"
+)
 
 type ProductService interface {
 	GetProducts(claims *jwtadapter.AccessClaims, opts ...RetrieveOptsFunc) ([]Product, error)
@@ -8,4 +11,10 @@ type ProductService interface {
 	GetProductByVariantID(claims *jwtadapter.AccessClaims, id string, opts ...RetrieveOptsFunc) (*Product, error)
 	CreateProduct(claims *jwtadapter.AccessClaims, prod *Product, opts ...RetrieveOptsFunc) (*Product, error)
 	UpdateProductByID(claims *jwtadapter.AccessClaims, id string, prod *Product, opts ...RetrieveOptsFunc) (*Product, error)
+	EstimateVariant(claims *jwtadapter.AccessClaims, v *Variant) (*Estimate, error)
+	SetVariantTierPrices(claims *jwtadapter.AccessClaims, variantID string, prices []TierPrice) (*Product, error)
+	RepriceByMaterial(claims *jwtadapter.AccessClaims, materialID string) (*RepricingResult, error)
+	GetPriceReviews(claims *jwtadapter.AccessClaims) ([]PriceReview, error)
+	GetCapacities(claims *jwtadapter.AccessClaims) ([]Capacity, error)
+	GetShortfalls(claims *jwtadapter.AccessClaims, wishes []Wish) ([]material.Shortage, error)
 }
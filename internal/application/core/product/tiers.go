@@ -0,0 +1,108 @@
+package product
+
+import (
+	"cmp"
+	"slices"
+
+	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+	"github.com/omareloui/odinls/internal/errs"
+)
+
+// TierPrice is the variant's price in a tier when ordering at least the
+// minimum quantity, it's how the quantity breaks are set.
+type TierPrice struct {
+	Tier        PriceTierEnum `json:"tier" bson:"tier" validate:"required"`
+	MinQuantity uint16        `json:"min_quantity" bson:"min_quantity" validate:"required,min=1"`
+	Price       float64       `json:"price" bson:"price" validate:"required,gt=0"`
+}
+
+// BasePrice is the variant's price in the tier before any quantity break. The
+// distributors fall back to the wholesale price, and the wholesale to the
+// retail one.
+func (v *Variant) BasePrice(tier PriceTierEnum) float64 {
+	switch tier {
+	case PriceTierDistributor:
+		if p, ok := v.tierPrice(tier, 1); ok {
+			return p
+		}
+		fallthrough
+	case PriceTierWholesale:
+		if v.WholesalePrice > 0 {
+			return v.WholesalePrice
+		}
+	}
+	return v.Price
+}
+
+// PriceFor is the unit price in the tier for ordering the quantity, it's the
+// biggest quantity break reached or the tier's base price.
+func (v *Variant) PriceFor(tier PriceTierEnum, quantity uint16) float64 {
+	if p, ok := v.tierPrice(tier, quantity); ok {
+		return p
+	}
+	return v.BasePrice(tier)
+}
+
+func (v *Variant) tierPrice(tier PriceTierEnum, quantity uint16) (float64, bool) {
+	var best *TierPrice
+	for i, tp := range v.TierPrices {
+		if tp.Tier != tier || tp.MinQuantity > quantity {
+			continue
+		}
+		if best == nil || tp.MinQuantity > best.MinQuantity {
+			best = &v.TierPrices[i]
+		}
+	}
+	if best == nil {
+		return 0, false
+	}
+	return best.Price, true
+}
+
+// SetVariantTierPrices replaces the variant's tier prices, a repeated tier and
+// minimum quantity keeps the last price.
+func (s *productService) SetVariantTierPrices(claims *jwtadapter.AccessClaims, variantID string, prices []TierPrice) (*Product, error) {
+	if claims == nil || !claims.Role.IsAdmin() {
+		return nil, errs.ErrForbidden
+	}
+
+	tierPrices := make([]TierPrice, 0, len(prices))
+	for i := range prices {
+		if err := s.sanitizer.SanitizeStruct(&prices[i]); err != nil {
+			return nil, errs.ErrSanitizer
+		}
+		if err := s.validator.Validate(&prices[i]); err != nil {
+			return nil, err
+		}
+		if !slices.Contains(PriceTiersEnums(), prices[i].Tier) {
+			return nil, errs.ErrInvalidEnum
+		}
+
+		tierPrices = slices.DeleteFunc(tierPrices, func(tp TierPrice) bool {
+			return tp.Tier == prices[i].Tier && tp.MinQuantity == prices[i].MinQuantity
+		})
+		tierPrices = append(tierPrices, prices[i])
+	}
+
+	slices.SortFunc(tierPrices, func(a, b TierPrice) int {
+		if a.Tier != b.Tier {
+			return slices.Index(PriceTiersEnums(), a.Tier) - slices.Index(PriceTiersEnums(), b.Tier)
+		}
+		return cmp.Compare(a.MinQuantity, b.MinQuantity)
+	})
+
+	prod, err := s.repo.GetProductByVariantID(variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := slices.IndexFunc(prod.Variants, func(v Variant) bool {
+		return v.ID == variantID
+	})
+	if idx == -1 {
+		return nil, errs.ErrDocumentNotFound
+	}
+	prod.Variants[idx].TierPrices = tierPrices
+
+	return s.repo.UpdateProductByID(prod.ID, prod)
+}
@@ -0,0 +1,57 @@
+package product
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriceFor(t *testing.T) {
+	variant := Variant{
+		Price:          500,
+		WholesalePrice: 400,
+		TierPrices: []TierPrice{
+			{Tier: PriceTierWholesale, MinQuantity: 10, Price: 380},
+			{Tier: PriceTierWholesale, MinQuantity: 50, Price: 350},
+			{Tier: PriceTierDistributor, MinQuantity: 1, Price: 320},
+			{Tier: PriceTierDistributor, MinQuantity: 100, Price: 290},
+		},
+	}
+	retailOnly := Variant{Price: 500}
+
+	tests := []struct {
+		name     string
+		variant  Variant
+		tier     PriceTierEnum
+		quantity uint16
+		want     float64
+	}{
+		{"retail", variant, PriceTierRetail, 100, 500},
+		{"wholesale under the first break", variant, PriceTierWholesale, 9, 400},
+		{"wholesale at a break", variant, PriceTierWholesale, 10, 380},
+		{"wholesale between the breaks", variant, PriceTierWholesale, 49, 380},
+		{"wholesale past the biggest break", variant, PriceTierWholesale, 200, 350},
+		{"distributor base price", variant, PriceTierDistributor, 1, 320},
+		{"distributor break", variant, PriceTierDistributor, 100, 290},
+		{"wholesale falls back to retail", retailOnly, PriceTierWholesale, 1, 500},
+		{"distributor falls back to retail", retailOnly, PriceTierDistributor, 1, 500},
+		{"empty tier is retail", variant, "", 100, 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.variant.PriceFor(tt.tier, tt.quantity))
+		})
+	}
+}
+
+func TestBasePriceDistributorFallsBackToWholesale(t *testing.T) {
+	variant := Variant{
+		Price:          500,
+		WholesalePrice: 400,
+		TierPrices:     []TierPrice{{Tier: PriceTierDistributor, MinQuantity: 20, Price: 300}},
+	}
+
+	assert.Equal(t, 400.0, variant.BasePrice(PriceTierDistributor))
+	assert.Equal(t, 300.0, variant.PriceFor(PriceTierDistributor, 20))
+}
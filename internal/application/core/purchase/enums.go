@@ -0,0 +1,57 @@
+package purchase
+
+type StatusEnum string
+
+const (
+	StatusDraft             StatusEnum = "DRAFT"
+	StatusSent              StatusEnum = "SENT"
+	StatusPartiallyReceived StatusEnum = "PARTIALLY_RECEIVED"
+	StatusReceived          StatusEnum = "RECEIVED"
+	StatusCancelled         StatusEnum = "CANCELLED"
+)
+
+func (s StatusEnum) View() string {
+	v := map[StatusEnum]string{
+		StatusDraft:             "Draft",
+		StatusSent:              "Sent",
+		StatusPartiallyReceived: "Partially Received",
+		StatusReceived:          "Received",
+		StatusCancelled:         "Cancelled",
+	}[s]
+	if v == "" {
+		return StatusDraft.View()
+	}
+	return v
+}
+
+func StatusesEnums() []StatusEnum {
+	return []StatusEnum{
+		StatusDraft, StatusSent, StatusPartiallyReceived,
+		StatusReceived, StatusCancelled,
+	}
+}
+
+// PeriodEnum is how long the periods the supplier's spend is totaled by are.
+type PeriodEnum string
+
+const (
+	PeriodMonth   PeriodEnum = "MONTH"
+	PeriodQuarter PeriodEnum = "QUARTER"
+	PeriodYear    PeriodEnum = "YEAR"
+)
+
+func (p PeriodEnum) View() string {
+	v := map[PeriodEnum]string{
+		PeriodMonth:   "Monthly",
+		PeriodQuarter: "Quarterly",
+		PeriodYear:    "Yearly",
+	}[p]
+	if v == "" {
+		return PeriodMonth.View()
+	}
+	return v
+}
+
+func PeriodsEnums() []PeriodEnum {
+	return []PeriodEnum{PeriodMonth, PeriodQuarter, PeriodYear}
+}
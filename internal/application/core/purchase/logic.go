@@ -0,0 +1,258 @@
+package purchase
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+	"github.com/omareloui/odinls/internal/application/core/material"
+	"github.com/omareloui/odinls/internal/application/core/stock"
+	"github.com/omareloui/odinls/internal/application/core/supplier"
+	"github.com/omareloui/odinls/internal/errs"
+	"github.com/omareloui/odinls/internal/interfaces"
+)
+
+type purchaseService struct {
+	repo      PurchaseRepository
+	validator interfaces.Validator
+	sanitizer interfaces.Sanitizer
+
+	materialService material.MaterialService
+	supplierService supplier.SupplierService
+	stockService    stock.StockService
+}
+
+func NewPurchaseService(repo PurchaseRepository, materialService material.MaterialService, supplierService supplier.SupplierService, stockService stock.StockService, validator interfaces.Validator, sanitizer interfaces.Sanitizer) *purchaseService {
+	return &purchaseService{
+		repo:            repo,
+		validator:       validator,
+		sanitizer:       sanitizer,
+		materialService: materialService,
+		supplierService: supplierService,
+		stockService:    stockService,
+	}
+}
+
+func (s *purchaseService) GetPurchaseOrders(claims *jwtadapter.AccessClaims, options ...RetrieveOptsFunc) ([]PurchaseOrder, error) {
+	if claims == nil || !claims.Role.IsModerator() {
+		return nil, errs.ErrForbidden
+	}
+
+	return s.repo.GetPurchaseOrders(options...)
+}
+
+func (s *purchaseService) GetSupplierPurchaseOrders(claims *jwtadapter.AccessClaims, supplierID string, options ...RetrieveOptsFunc) ([]PurchaseOrder, error) {
+	if claims == nil || !claims.Role.IsModerator() {
+		return nil, errs.ErrForbidden
+	}
+
+	return s.repo.GetPurchaseOrdersBySupplierID(supplierID, options...)
+}
+
+func (s *purchaseService) GetPurchaseOrderByID(claims *jwtadapter.AccessClaims, id string, options ...RetrieveOptsFunc) (*PurchaseOrder, error) {
+	if claims == nil || !claims.Role.IsModerator() {
+		return nil, errs.ErrForbidden
+	}
+
+	return s.repo.GetPurchaseOrderByID(id, options...)
+}
+
+func (s *purchaseService) CreatePurchaseOrder(claims *jwtadapter.AccessClaims, po *PurchaseOrder, options ...RetrieveOptsFunc) (*PurchaseOrder, error) {
+	if claims == nil || !claims.Role.IsAdmin() {
+		return nil, errs.ErrForbidden
+	}
+
+	err := s.sanitizer.SanitizeStruct(po)
+	if err != nil {
+		return nil, errs.ErrSanitizer
+	}
+
+	if err := s.validator.Validate(po); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.supplierService.GetSupplierByID(claims, po.SupplierID); err != nil {
+		return nil, err
+	}
+
+	// A material can only be listed once, each line has its own price.
+	lines := make([]Line, 0, len(po.Lines))
+	for i, line := range po.Lines {
+		mat, err := s.materialService.GetMaterialByID(claims, line.MaterialID)
+		if err != nil {
+			return nil, err
+		}
+
+		if slices.ContainsFunc(lines, func(l Line) bool { return l.MaterialID == mat.ID }) {
+			return nil, &LineError{Index: i, Err: ErrDuplicateLine}
+		}
+
+		quantity, err := mat.Normalize(line.Quantity, line.Unit)
+		if err != nil {
+			return nil, &LineError{Index: i, Err: err}
+		}
+		if quantity > 0 {
+			line.UnitPrice = line.UnitPrice * line.Quantity / quantity
+		}
+		line.Quantity = quantity
+
+		line.MaterialName = mat.Name
+		line.Unit = mat.Unit
+		line.ReceivedQuantity = 0
+		if line.UnitPrice == 0 {
+			line.UnitPrice = mat.PricePerUnit
+		}
+		lines = append(lines, line)
+	}
+
+	po.Lines = lines
+	po.Status = StatusDraft
+	po.Receipts = nil
+
+	return s.repo.CreatePurchaseOrder(po, options...)
+}
+
+func (s *purchaseService) TransitionPurchaseOrderStatus(claims *jwtadapter.AccessClaims, id string, to StatusEnum, options ...RetrieveOptsFunc) (*PurchaseOrder, error) {
+	if claims == nil || !claims.Role.IsAdmin() {
+		return nil, errs.ErrForbidden
+	}
+
+	po, err := s.repo.GetPurchaseOrderByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The received statuses are only reached by receiving the goods.
+	if to.isReceiving() {
+		return nil, &StatusTransitionError{From: po.Status, To: to}
+	}
+
+	if err := po.TransitionTo(to, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if to == StatusSent && po.ExpectedOn.IsZero() {
+		sup, err := s.supplierService.GetSupplierByID(claims, po.SupplierID)
+		if err != nil {
+			return nil, err
+		}
+		if sup.LeadTimeDays > 0 {
+			po.ExpectedOn = po.SentOn.AddDate(0, 0, sup.LeadTimeDays)
+		}
+	}
+
+	return s.repo.UpdatePurchaseOrderByID(id, po, options...)
+}
+
+// ReceiveGoods adds the received quantities to the materials' stock as
+// purchase movements and updates the materials' prices to the received cost.
+// A receipt without a unit price is received at the line's agreed price, and
+// its rejected quantity is only recorded for the supplier's scorecard.
+//
+// The receipts are saved on the purchase order before getting into the stock,
+// and the ones that fail to get into it are taken back off. A receipt in the
+// stock stays received, if recording its lot fails the error is returned with
+// the updated purchase order.
+func (s *purchaseService) ReceiveGoods(claims *jwtadapter.AccessClaims, id string, receipts []Receipt, options ...RetrieveOptsFunc) (*PurchaseOrder, error) {
+	if claims == nil || !claims.Role.IsAdmin() {
+		return nil, errs.ErrForbidden
+	}
+
+	now := time.Now()
+
+	// The receipts are only saved if no other receipts were saved since the
+	// purchase order was read, so together they can't receive more than
+	// what's remaining of a line.
+	for range receiveAttempts {
+		po, err := s.repo.GetPurchaseOrderByID(id, options...)
+		if err != nil {
+			return nil, err
+		}
+
+		received, added, err := po.withReceipts(receipts, now)
+		if err != nil {
+			return nil, err
+		}
+		if len(added) == 0 {
+			return po, nil
+		}
+
+		updated, err := s.repo.UpdatePurchaseOrderReceipts(id, received, len(po.Receipts), options...)
+		if errors.Is(err, errs.ErrDocumentNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return s.stockReceipts(claims, po, updated, added, now)
+	}
+
+	return nil, ErrReceiptsChanged
+}
+
+// GetSupplierScorecard scores the supplier's deliveries, defaulting to the
+// monthly spend.
+func (s *purchaseService) GetSupplierScorecard(claims *jwtadapter.AccessClaims, supplierID string, period PeriodEnum) (*Scorecard, error) {
+	if claims == nil || !claims.Role.IsModerator() {
+		return nil, errs.ErrForbidden
+	}
+
+	if period == "" {
+		period = PeriodMonth
+	}
+	if !slices.Contains(PeriodsEnums(), period) {
+		return nil, errs.ErrInvalidEnum
+	}
+
+	sup, err := s.supplierService.GetSupplierByID(claims, supplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := s.repo.GetPurchaseOrdersBySupplierID(supplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewScorecard(sup, pos, period), nil
+}
+
+// stockReceipts puts the receipts added to the purchase order into the stock
+// and records their lots. If one of them fails to get into the stock, it and
+// the receipts after it are taken back off the purchase order.
+func (s *purchaseService) stockReceipts(claims *jwtadapter.AccessClaims, po, updated *PurchaseOrder, added []Receipt, at time.Time) (*PurchaseOrder, error) {
+	lotErrs := []error{}
+
+	for i, receipt := range added {
+		if receipt.Quantity == 0 {
+			continue
+		}
+
+		_, err := s.stockService.RecordMovement(claims, &stock.Movement{
+			MaterialID: receipt.MaterialID,
+			Quantity:   receipt.Quantity,
+			Reason:     stock.ReasonPurchase,
+			Reference:  po.ID,
+		})
+		if err != nil {
+			stocked, _, _ := po.withReceipts(added[:i], at)
+			if _, rerr := s.repo.UpdatePurchaseOrderReceipts(po.ID, stocked, len(updated.Receipts)); rerr != nil {
+				return nil, errors.Join(err, rerr)
+			}
+			return nil, err
+		}
+
+		_, err = s.materialService.ReceiveLot(claims, receipt.MaterialID, material.Lot{
+			Quantity:  receipt.Quantity,
+			UnitPrice: receipt.UnitPrice,
+			Date:      receipt.Date,
+		})
+		if err != nil {
+			lotErrs = append(lotErrs, err)
+		}
+	}
+
+	return updated, errors.Join(lotErrs...)
+}
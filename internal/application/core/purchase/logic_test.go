@@ -0,0 +1,195 @@
+package purchase_test
+
+import (
+	"errors"
+	"testing"
+
+	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+	"github.com/omareloui/odinls/internal/application/core/material"
+	material_mock "github.com/omareloui/odinls/internal/application/core/material/mocks"
+	"github.com/omareloui/odinls/internal/application/core/purchase"
+	purchase_mock "github.com/omareloui/odinls/internal/application/core/purchase/mocks"
+	"github.com/omareloui/odinls/internal/application/core/stock"
+	stock_mock "github.com/omareloui/odinls/internal/application/core/stock/mocks"
+	"github.com/omareloui/odinls/internal/application/core/user"
+	"github.com/omareloui/odinls/internal/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var admin = &jwtadapter.AccessClaims{Role: user.Admin}
+
+// sentPO is a purchase order of 10 of m1 and 4 of m2, with 2 of m1
+// already received.
+func sentPO() *purchase.PurchaseOrder {
+	return &purchase.PurchaseOrder{
+		ID:     "po1",
+		Status: purchase.StatusPartiallyReceived,
+		Lines: []purchase.Line{
+			{MaterialID: "m1", Quantity: 10, UnitPrice: 5, ReceivedQuantity: 2},
+			{MaterialID: "m2", Quantity: 4, UnitPrice: 20},
+		},
+		Receipts: []purchase.Receipt{{MaterialID: "m1", Quantity: 2, UnitPrice: 5}},
+	}
+}
+
+type receiveMocks struct {
+	repo     *purchase_mock.MockPurchaseRepository
+	material *material_mock.MockMaterialService
+	stock    *stock_mock.MockStockService
+}
+
+func newReceiveMocks(t *testing.T) (*receiveMocks, purchase.PurchaseService) {
+	m := &receiveMocks{
+		repo:     purchase_mock.NewMockPurchaseRepository(t),
+		material: material_mock.NewMockMaterialService(t),
+		stock:    stock_mock.NewMockStockService(t),
+	}
+	return m, purchase.NewPurchaseService(m.repo, m.material, nil, m.stock, nil, nil)
+}
+
+// returnSaved makes the receipts update return the purchase order it saves.
+func returnSaved(_ string, po *purchase.PurchaseOrder, _ int, _ ...purchase.RetrieveOptsFunc) (*purchase.PurchaseOrder, error) {
+	return po, nil
+}
+
+func TestReceiveGoods(t *testing.T) {
+	tests := []struct {
+		name         string
+		receipts     []purchase.Receipt
+		wantErr      error
+		wantStatus   purchase.StatusEnum
+		wantReceived []float64
+		wantStocked  int
+	}{
+		{
+			"part of the goods",
+			[]purchase.Receipt{{MaterialID: "m1", Quantity: 3}},
+			nil, purchase.StatusPartiallyReceived, []float64{5, 0}, 1,
+		},
+		{
+			"all the remaining goods",
+			[]purchase.Receipt{{MaterialID: "m1", Quantity: 5}, {MaterialID: "m1", Quantity: 3}, {MaterialID: "m2", Quantity: 4}},
+			nil, purchase.StatusReceived, []float64{10, 4}, 3,
+		},
+		{
+			"rejected goods aren't stocked",
+			[]purchase.Receipt{{MaterialID: "m2", Rejected: 4}},
+			nil, purchase.StatusPartiallyReceived, []float64{2, 0}, 0,
+		},
+		{
+			"more than the remaining quantity",
+			[]purchase.Receipt{{MaterialID: "m1", Quantity: 9}},
+			errs.ErrInvalidNumber, "", nil, 0,
+		},
+		{
+			"more than the remaining quantity in total",
+			[]purchase.Receipt{{MaterialID: "m1", Quantity: 5}, {MaterialID: "m1", Quantity: 4}},
+			errs.ErrInvalidNumber, "", nil, 0,
+		},
+		{
+			"negative quantity",
+			[]purchase.Receipt{{MaterialID: "m1", Quantity: -1}},
+			errs.ErrInvalidNumber, "", nil, 0,
+		},
+		{
+			"material not in the lines",
+			[]purchase.Receipt{{MaterialID: "m3", Quantity: 1}},
+			errs.ErrDocumentNotFound, "", nil, 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, svc := newReceiveMocks(t)
+			m.repo.On("GetPurchaseOrderByID", "po1").Return(sentPO(), nil)
+			if tt.wantErr == nil {
+				m.repo.On("UpdatePurchaseOrderReceipts", "po1", mock.Anything, 1).Return(returnSaved)
+			}
+			if tt.wantStocked > 0 {
+				m.stock.On("RecordMovement", admin, mock.Anything).Return(&stock.Movement{}, nil)
+				m.material.On("ReceiveLot", admin, mock.Anything, mock.Anything).Return(&material.Material{}, nil)
+			}
+
+			po, err := svc.ReceiveGoods(admin, "po1", tt.receipts)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, po)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.wantStatus, po.Status)
+			assert.Equal(t, tt.wantReceived, []float64{po.Lines[0].ReceivedQuantity, po.Lines[1].ReceivedQuantity})
+			assert.Len(t, po.Receipts, 1+len(tt.receipts))
+			m.stock.AssertNumberOfCalls(t, "RecordMovement", tt.wantStocked)
+			m.material.AssertNumberOfCalls(t, "ReceiveLot", tt.wantStocked)
+		})
+	}
+}
+
+func TestReceiveGoodsFailures(t *testing.T) {
+	receipts := []purchase.Receipt{{MaterialID: "m1", Quantity: 3}, {MaterialID: "m2", Quantity: 4}}
+	errStock := errors.New("stock failure")
+
+	t.Run("the receipts that didn't get stocked are taken back", func(t *testing.T) {
+		m, svc := newReceiveMocks(t)
+		m.repo.On("GetPurchaseOrderByID", "po1").Return(sentPO(), nil)
+		m.repo.On("UpdatePurchaseOrderReceipts", "po1", mock.Anything, 1).Return(returnSaved).Once()
+		m.repo.On("UpdatePurchaseOrderReceipts", "po1", mock.MatchedBy(func(po *purchase.PurchaseOrder) bool {
+			return len(po.Receipts) == 2 && po.Lines[0].ReceivedQuantity == 5 && po.Lines[1].ReceivedQuantity == 0
+		}), 3).Return(returnSaved).Once()
+		m.stock.On("RecordMovement", admin, mock.MatchedBy(func(mv *stock.Movement) bool { return mv.MaterialID == "m1" })).Return(&stock.Movement{}, nil)
+		m.stock.On("RecordMovement", admin, mock.MatchedBy(func(mv *stock.Movement) bool { return mv.MaterialID == "m2" })).Return(nil, errStock)
+		m.material.On("ReceiveLot", admin, "m1", mock.Anything).Return(&material.Material{}, nil)
+
+		po, err := svc.ReceiveGoods(admin, "po1", receipts)
+		assert.ErrorIs(t, err, errStock)
+		assert.Nil(t, po)
+	})
+
+	t.Run("a failed lot keeps the receipts", func(t *testing.T) {
+		errLot := errors.New("lot failure")
+		m, svc := newReceiveMocks(t)
+		m.repo.On("GetPurchaseOrderByID", "po1").Return(sentPO(), nil)
+		m.repo.On("UpdatePurchaseOrderReceipts", "po1", mock.Anything, 1).Return(returnSaved).Once()
+		m.stock.On("RecordMovement", admin, mock.Anything).Return(&stock.Movement{}, nil)
+		m.material.On("ReceiveLot", admin, "m1", mock.Anything).Return(nil, errLot)
+		m.material.On("ReceiveLot", admin, "m2", mock.Anything).Return(&material.Material{}, nil)
+
+		po, err := svc.ReceiveGoods(admin, "po1", receipts)
+		assert.ErrorIs(t, err, errLot)
+		if assert.NotNil(t, po) {
+			assert.Len(t, po.Receipts, 3)
+		}
+		m.stock.AssertNumberOfCalls(t, "RecordMovement", 2)
+	})
+
+	t.Run("receipts saved meanwhile are checked again", func(t *testing.T) {
+		m, svc := newReceiveMocks(t)
+		meanwhile := sentPO()
+		meanwhile.Lines[0].ReceivedQuantity = 8
+		meanwhile.Receipts = append(meanwhile.Receipts, purchase.Receipt{MaterialID: "m1", Quantity: 6, UnitPrice: 5})
+
+		m.repo.On("GetPurchaseOrderByID", "po1").Return(sentPO(), nil).Once()
+		m.repo.On("GetPurchaseOrderByID", "po1").Return(meanwhile, nil).Once()
+		m.repo.On("UpdatePurchaseOrderReceipts", "po1", mock.Anything, 1).Return(nil, errs.ErrDocumentNotFound).Once()
+
+		po, err := svc.ReceiveGoods(admin, "po1", receipts)
+		assert.ErrorIs(t, err, errs.ErrInvalidNumber)
+		assert.Nil(t, po)
+	})
+
+	t.Run("gives up when the receipts keep changing", func(t *testing.T) {
+		m, svc := newReceiveMocks(t)
+		m.repo.On("GetPurchaseOrderByID", "po1").Return(sentPO(), nil)
+		m.repo.On("UpdatePurchaseOrderReceipts", "po1", mock.Anything, 1).Return(nil, errs.ErrDocumentNotFound)
+
+		po, err := svc.ReceiveGoods(admin, "po1", receipts)
+		assert.ErrorIs(t, err, purchase.ErrReceiptsChanged)
+		assert.Nil(t, po)
+		m.repo.AssertNumberOfCalls(t, "GetPurchaseOrderByID", 3)
+	})
+}
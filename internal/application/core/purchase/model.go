@@ -0,0 +1,185 @@
+// Package purchase is meant for the purchase orders sent to the suppliers and
+// receiving their goods into the materials stock.
+package purchase
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/omareloui/odinls/internal/application/core/material"
+	"github.com/omareloui/odinls/internal/application/core/supplier"
+	"github.com/omareloui/odinls/internal/errs"
+)
+
+var (
+	ErrDuplicateLine   = fmt.Errorf("%w: the material is already in another line", errs.ErrDocumentAlreadyExists)
+	ErrReceiptsChanged = fmt.Errorf("%w: other goods are being received, try again", errs.ErrInvalidTransition)
+)
+
+// receiveAttempts is how many times the receipts are tried to be saved while
+// other receipts are being saved to the purchase order.
+const receiveAttempts = 3
+
+type PurchaseOrder struct {
+	ID string `json:"id" bson:"_id,omitempty" formfield:"-"`
+
+	SupplierID string     `json:"supplier_id" bson:"supplier" formfield:"supplier_id" validate:"required,mongodb"`
+	Status     StatusEnum `json:"status" bson:"status" formfield:"-"`
+
+	Lines    []Line    `json:"lines" bson:"lines" formfield:"-" validate:"required,min=1,dive"`
+	Receipts []Receipt `json:"receipts" bson:"receipts,omitempty" formfield:"-"`
+
+	Note string `json:"note" bson:"note,omitempty" formfield:"note" conform:"trim"`
+
+	SentOn time.Time `json:"sent_on,omitzero" bson:"sent_on,omitempty" formfield:"-"`
+	// ExpectedOn is when the supplier should deliver the goods, it defaults
+	// to the supplier's lead time after sending the order.
+	ExpectedOn time.Time `json:"expected_on,omitzero" bson:"expected_on,omitempty" formfield:"expected_on"`
+	ResolvedOn time.Time `json:"resolved_on,omitzero" bson:"resolved_on,omitempty" formfield:"-"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+
+	Supplier *supplier.Supplier `json:"supplier" bson:"populated_supplier,omitempty" formfield:"-"`
+}
+
+// Line is a material to purchase with the unit price agreed on with the
+// supplier. The material's name and unit are kept as they were when the
+// order got created, a line entered in another unit is converted to the
+// material's.
+type Line struct {
+	MaterialID   string        `json:"material_id" bson:"material_id" validate:"required,mongodb"`
+	MaterialName string        `json:"material_name" bson:"material_name"`
+	Unit         material.Unit `json:"unit" bson:"unit"`
+
+	Quantity         float64 `json:"quantity" bson:"quantity" validate:"required,gt=0"`
+	UnitPrice        float64 `json:"unit_price" bson:"unit_price" validate:"gte=0"`
+	ReceivedQuantity float64 `json:"received_quantity" bson:"received_quantity"`
+}
+
+// LineError is an error with one of the purchase order's lines.
+type LineError struct {
+	Index int
+	Err   error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line #%d: %s", e.Index+1, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+func (l *Line) Total() float64 {
+	return l.Quantity * l.UnitPrice
+}
+
+func (l *Line) Remaining() float64 {
+	return max(l.Quantity-l.ReceivedQuantity, 0)
+}
+
+// Receipt is a quantity of a material that got received, with the cost it
+// was received at. The rejected quantity got returned to the supplier, it
+// doesn't go into the stock nor count as received.
+type Receipt struct {
+	MaterialID string    `json:"material_id" bson:"material_id"`
+	Quantity   float64   `json:"quantity" bson:"quantity"`
+	Rejected   float64   `json:"rejected" bson:"rejected,omitempty"`
+	UnitPrice  float64   `json:"unit_price" bson:"unit_price"`
+	Date       time.Time `json:"date" bson:"date"`
+}
+
+func (r *Receipt) Total() float64 {
+	return r.Quantity * r.UnitPrice
+}
+
+func (po *PurchaseOrder) Total() float64 {
+	var total float64
+	for _, line := range po.Lines {
+		total += line.Total()
+	}
+	return total
+}
+
+func (po *PurchaseOrder) LineByMaterialID(materialID string) (*Line, bool) {
+	for i := range po.Lines {
+		if po.Lines[i].MaterialID == materialID {
+			return &po.Lines[i], true
+		}
+	}
+	return nil, false
+}
+
+func (po *PurchaseOrder) fullyReceived() bool {
+	for _, line := range po.Lines {
+		if line.Remaining() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// withReceipts is a copy of the purchase order with the receipts added and its
+// status moved to match, and the receipts that got added. The receipts of the
+// same material are totaled, so together they can't receive more than what's
+// remaining of the line.
+func (po *PurchaseOrder) withReceipts(receipts []Receipt, at time.Time) (*PurchaseOrder, []Receipt, error) {
+	if !po.Status.CanReceive() {
+		return nil, nil, &StatusTransitionError{From: po.Status, To: StatusReceived}
+	}
+
+	totals := map[string]float64{}
+	for _, receipt := range receipts {
+		line, ok := po.LineByMaterialID(receipt.MaterialID)
+		if !ok {
+			return nil, nil, errs.ErrDocumentNotFound
+		}
+		if receipt.Quantity < 0 || receipt.Rejected < 0 || receipt.UnitPrice < 0 {
+			return nil, nil, errs.ErrInvalidNumber
+		}
+		totals[receipt.MaterialID] += receipt.Quantity
+		if totals[receipt.MaterialID] > line.Remaining() {
+			return nil, nil, errs.ErrInvalidNumber
+		}
+	}
+
+	received := *po
+	received.Lines = slices.Clone(po.Lines)
+	received.Receipts = slices.Clone(po.Receipts)
+
+	added := []Receipt{}
+	stocked := false
+	for _, receipt := range receipts {
+		if receipt.Quantity == 0 && receipt.Rejected == 0 {
+			continue
+		}
+
+		line, _ := received.LineByMaterialID(receipt.MaterialID)
+		if receipt.UnitPrice == 0 {
+			receipt.UnitPrice = line.UnitPrice
+		}
+		receipt.Date = at
+
+		line.ReceivedQuantity += receipt.Quantity
+		stocked = stocked || receipt.Quantity > 0
+
+		received.Receipts = append(received.Receipts, receipt)
+		added = append(added, receipt)
+	}
+
+	if stocked {
+		to := StatusPartiallyReceived
+		if received.fullyReceived() {
+			to = StatusReceived
+		}
+		if to != received.Status {
+			if err := received.TransitionTo(to, at); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	return &received, added, nil
+}
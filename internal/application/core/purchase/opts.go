@@ -0,0 +1,20 @@
+package purchase
+
+type (
+	RetrieveOptsFunc func(*RetrieveOpts)
+	RetrieveOpts     struct {
+		PopulateSupplier bool
+	}
+)
+
+func WithPopulatedSupplier(opts *RetrieveOpts) {
+	opts.PopulateSupplier = true
+}
+
+func ParseRetrieveOpts(funcs ...RetrieveOptsFunc) *RetrieveOpts {
+	o := &RetrieveOpts{}
+	for _, fun := range funcs {
+		fun(o)
+	}
+	return o
+}
@@ -0,0 +1,13 @@
+package purchase
+
+type PurchaseRepository interface {
+	GetPurchaseOrders(opts ...RetrieveOptsFunc) ([]PurchaseOrder, error)
+	GetPurchaseOrdersBySupplierID(supplierID string, opts ...RetrieveOptsFunc) ([]PurchaseOrder, error)
+	GetPurchaseOrderByID(id string, opts ...RetrieveOptsFunc) (*PurchaseOrder, error)
+	CreatePurchaseOrder(po *PurchaseOrder, opts ...RetrieveOptsFunc) (*PurchaseOrder, error)
+	UpdatePurchaseOrderByID(id string, po *PurchaseOrder, opts ...RetrieveOptsFunc) (*PurchaseOrder, error)
+	// UpdatePurchaseOrderReceipts saves the purchase order's lines, receipts,
+	// and status only if it still has the given number of receipts, otherwise
+	// it fails with errs.ErrDocumentNotFound.
+	UpdatePurchaseOrderReceipts(id string, po *PurchaseOrder, receiptsCount int, opts ...RetrieveOptsFunc) (*PurchaseOrder, error)
+}
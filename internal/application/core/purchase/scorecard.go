@@ -0,0 +1,239 @@
+package purchase
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/omareloui/odinls/internal/application/core/material"
+	"github.com/omareloui/odinls/internal/application/core/supplier"
+)
+
+// Scorecard is how the supplier performed on its purchase orders, to compare
+// it with the other suppliers.
+type Scorecard struct {
+	Supplier *supplier.Supplier
+	Period   PeriodEnum
+
+	PurchaseOrders int
+	// Deliveries are the receipts of the purchase orders with an expected
+	// date, the ones that came after it are late.
+	Deliveries int
+	OnTime     int
+	DaysLate   int
+
+	Materials []MaterialScore
+	Spend     []PeriodSpend
+}
+
+// MaterialScore is what got received of a material from the supplier, and
+// how its price changed over the receipts.
+type MaterialScore struct {
+	MaterialID   string
+	MaterialName string
+	Unit         material.Unit
+
+	Received float64
+	Rejected float64
+	Spend    float64
+
+	FirstPrice float64
+	LastPrice  float64
+	MinPrice   float64
+	MaxPrice   float64
+}
+
+type PeriodSpend struct {
+	Period   string
+	Receipts int
+	Spend    float64
+}
+
+// NewScorecard scores the supplier by the receipts of its purchase orders.
+func NewScorecard(sup *supplier.Supplier, pos []PurchaseOrder, period PeriodEnum) *Scorecard {
+	sc := &Scorecard{Supplier: sup, Period: period}
+
+	type delivery struct {
+		po      *PurchaseOrder
+		receipt Receipt
+	}
+
+	deliveries := []delivery{}
+	for i := range pos {
+		if pos[i].Status == StatusDraft {
+			continue
+		}
+		sc.PurchaseOrders++
+		for _, receipt := range pos[i].Receipts {
+			deliveries = append(deliveries, delivery{po: &pos[i], receipt: receipt})
+		}
+	}
+	slices.SortStableFunc(deliveries, func(a, b delivery) int {
+		return a.receipt.Date.Compare(b.receipt.Date)
+	})
+
+	for _, d := range deliveries {
+		if !d.po.ExpectedOn.IsZero() {
+			sc.Deliveries++
+			if late := daysBetween(d.po.ExpectedOn, d.receipt.Date); late > 0 {
+				sc.DaysLate += late
+			} else {
+				sc.OnTime++
+			}
+		}
+
+		ms := sc.material(d.po, d.receipt.MaterialID)
+		ms.Rejected += d.receipt.Rejected
+		if d.receipt.Quantity == 0 {
+			continue
+		}
+
+		ms.Received += d.receipt.Quantity
+		ms.Spend += d.receipt.Total()
+
+		price := d.receipt.UnitPrice
+		if ms.FirstPrice == 0 {
+			ms.FirstPrice, ms.MinPrice, ms.MaxPrice = price, price, price
+		}
+		ms.LastPrice = price
+		ms.MinPrice = min(ms.MinPrice, price)
+		ms.MaxPrice = max(ms.MaxPrice, price)
+
+		ps := sc.period(period.Of(d.receipt.Date))
+		ps.Receipts++
+		ps.Spend += d.receipt.Total()
+	}
+
+	return sc
+}
+
+func (sc *Scorecard) material(po *PurchaseOrder, materialID string) *MaterialScore {
+	idx := slices.IndexFunc(sc.Materials, func(m MaterialScore) bool {
+		return m.MaterialID == materialID
+	})
+	if idx == -1 {
+		ms := MaterialScore{MaterialID: materialID}
+		if line, ok := po.LineByMaterialID(materialID); ok {
+			ms.MaterialName, ms.Unit = line.MaterialName, line.Unit
+		}
+		sc.Materials = append(sc.Materials, ms)
+		idx = len(sc.Materials) - 1
+	}
+	return &sc.Materials[idx]
+}
+
+func (sc *Scorecard) period(label string) *PeriodSpend {
+	idx := slices.IndexFunc(sc.Spend, func(p PeriodSpend) bool {
+		return p.Period == label
+	})
+	if idx == -1 {
+		sc.Spend = append(sc.Spend, PeriodSpend{Period: label})
+		idx = len(sc.Spend) - 1
+	}
+	return &sc.Spend[idx]
+}
+
+// OnTimeRate is the share of the deliveries that came by their expected date.
+func (sc *Scorecard) OnTimeRate() float64 {
+	if sc.Deliveries == 0 {
+		return 0
+	}
+	return float64(sc.OnTime) / float64(sc.Deliveries)
+}
+
+// AverageDaysLate is how late the late deliveries were on average.
+func (sc *Scorecard) AverageDaysLate() float64 {
+	late := sc.Deliveries - sc.OnTime
+	if late == 0 {
+		return 0
+	}
+	return float64(sc.DaysLate) / float64(late)
+}
+
+func (sc *Scorecard) TotalSpend() float64 {
+	var total float64
+	for _, p := range sc.Spend {
+		total += p.Spend
+	}
+	return total
+}
+
+// RejectionRate is the share of what the supplier delivered that got
+// rejected.
+func (m *MaterialScore) RejectionRate() float64 {
+	delivered := m.Received + m.Rejected
+	if delivered == 0 {
+		return 0
+	}
+	return m.Rejected / delivered
+}
+
+// PriceChange is how much the price changed from the first receipt to the
+// last one, as a fraction of the first price.
+func (m *MaterialScore) PriceChange() float64 {
+	if m.FirstPrice == 0 {
+		return 0
+	}
+	return (m.LastPrice - m.FirstPrice) / m.FirstPrice
+}
+
+// Of is the label of the period the date falls in.
+func (p PeriodEnum) Of(date time.Time) string {
+	switch p {
+	case PeriodYear:
+		return strconv.Itoa(date.Year())
+	case PeriodQuarter:
+		return fmt.Sprintf("%d-Q%d", date.Year(), (int(date.Month())-1)/3+1)
+	default:
+		return date.Format("2006-01")
+	}
+}
+
+// WriteCSV writes the scorecard as the summary, the materials, and the spend
+// per period tables, separated by empty lines.
+func (sc *Scorecard) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	rows := [][]string{
+		{"Supplier", "Purchase Orders", "Deliveries", "On Time", "On Time Rate", "Average Days Late", "Total Spend"},
+		{
+			sc.Supplier.Name, strconv.Itoa(sc.PurchaseOrders), strconv.Itoa(sc.Deliveries), strconv.Itoa(sc.OnTime),
+			formatCSVFloat(sc.OnTimeRate()), formatCSVFloat(sc.AverageDaysLate()), formatCSVFloat(sc.TotalSpend()),
+		},
+		{},
+		{"Material", "Unit", "Received", "Rejected", "Rejection Rate", "Spend", "First Price", "Last Price", "Min Price", "Max Price", "Price Change"},
+	}
+	for _, m := range sc.Materials {
+		rows = append(rows, []string{
+			m.MaterialName, string(m.Unit), formatCSVFloat(m.Received), formatCSVFloat(m.Rejected),
+			formatCSVFloat(m.RejectionRate()), formatCSVFloat(m.Spend), formatCSVFloat(m.FirstPrice),
+			formatCSVFloat(m.LastPrice), formatCSVFloat(m.MinPrice), formatCSVFloat(m.MaxPrice),
+			formatCSVFloat(m.PriceChange()),
+		})
+	}
+
+	rows = append(rows, []string{}, []string{"Period", "Receipts", "Spend"})
+	for _, p := range sc.Spend {
+		rows = append(rows, []string{p.Period, strconv.Itoa(p.Receipts), formatCSVFloat(p.Spend)})
+	}
+
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func formatCSVFloat(f float64) string {
+	return strconv.FormatFloat(math.Round(f*10000)/10000, 'f', -1, 64)
+}
+
+// daysBetween is how many calendar days the second date is after the first.
+func daysBetween(from, to time.Time) int {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
@@ -0,0 +1,13 @@
+package purchase
+
+import jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+
+type PurchaseService interface {
+	GetPurchaseOrders(claims *jwtadapter.AccessClaims, opts ...RetrieveOptsFunc) ([]PurchaseOrder, error)
+	GetSupplierPurchaseOrders(claims *jwtadapter.AccessClaims, supplierID string, opts ...RetrieveOptsFunc) ([]PurchaseOrder, error)
+	GetPurchaseOrderByID(claims *jwtadapter.AccessClaims, id string, opts ...RetrieveOptsFunc) (*PurchaseOrder, error)
+	CreatePurchaseOrder(claims *jwtadapter.AccessClaims, po *PurchaseOrder, opts ...RetrieveOptsFunc) (*PurchaseOrder, error)
+	TransitionPurchaseOrderStatus(claims *jwtadapter.AccessClaims, id string, to StatusEnum, opts ...RetrieveOptsFunc) (*PurchaseOrder, error)
+	ReceiveGoods(claims *jwtadapter.AccessClaims, id string, receipts []Receipt, opts ...RetrieveOptsFunc) (*PurchaseOrder, error)
+	GetSupplierScorecard(claims *jwtadapter.AccessClaims, supplierID string, period PeriodEnum) (*Scorecard, error)
+}
@@ -0,0 +1,80 @@
+package purchase
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/omareloui/odinls/internal/errs"
+)
+
+// statusTransitions is the purchase order lifecycle. The received statuses
+// are only reached by receiving the goods.
+var statusTransitions = map[StatusEnum][]StatusEnum{
+	StatusDraft:             {StatusSent, StatusCancelled},
+	StatusSent:              {StatusPartiallyReceived, StatusReceived, StatusCancelled},
+	StatusPartiallyReceived: {StatusReceived, StatusCancelled},
+	StatusReceived:          {},
+	StatusCancelled:         {},
+}
+
+type StatusTransitionError struct {
+	From StatusEnum
+	To   StatusEnum
+}
+
+func (e *StatusTransitionError) Error() string {
+	return fmt.Sprintf("can't move the purchase order from %q to %q", e.From.View(), e.To.View())
+}
+
+func (e *StatusTransitionError) Unwrap() error {
+	return errs.ErrInvalidTransition
+}
+
+// NextStatuses are the statuses the purchase order can be moved to by hand.
+func (s StatusEnum) NextStatuses() []StatusEnum {
+	return slices.DeleteFunc(slices.Clone(statusTransitions[s]), func(to StatusEnum) bool {
+		return to.isReceiving()
+	})
+}
+
+func (s StatusEnum) CanMoveTo(to StatusEnum) bool {
+	return slices.Contains(statusTransitions[s], to)
+}
+
+func (s StatusEnum) CanReceive() bool {
+	return s == StatusSent || s == StatusPartiallyReceived
+}
+
+func (s StatusEnum) IsFinal() bool {
+	next, ok := statusTransitions[s]
+	return ok && len(next) == 0
+}
+
+func (s StatusEnum) isReceiving() bool {
+	return s == StatusPartiallyReceived || s == StatusReceived
+}
+
+// TransitionTo moves the purchase order to the given status if the move is
+// allowed and stamps the matching date.
+func (po *PurchaseOrder) TransitionTo(to StatusEnum, at time.Time) error {
+	from := po.Status
+	if from == "" {
+		from = StatusDraft
+	}
+
+	if !from.CanMoveTo(to) {
+		return &StatusTransitionError{From: from, To: to}
+	}
+
+	po.Status = to
+
+	switch to {
+	case StatusSent:
+		po.SentOn = at
+	case StatusReceived, StatusCancelled:
+		po.ResolvedOn = at
+	}
+
+	return nil
+}
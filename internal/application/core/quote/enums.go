@@ -0,0 +1,32 @@
+package quote
+
+type StatusEnum string
+
+const (
+	StatusDraft    StatusEnum = "DRAFT"
+	StatusSent     StatusEnum = "SENT"
+	StatusAccepted StatusEnum = "ACCEPTED"
+	StatusRejected StatusEnum = "REJECTED"
+	StatusExpired  StatusEnum = "EXPIRED"
+)
+
+func (s StatusEnum) View() string {
+	v := map[StatusEnum]string{
+		StatusDraft:    "Draft",
+		StatusSent:     "Sent",
+		StatusAccepted: "Accepted",
+		StatusRejected: "Rejected",
+		StatusExpired:  "Expired",
+	}[s]
+	if v == "" {
+		return StatusDraft.View()
+	}
+	return v
+}
+
+func StatusesEnums() []StatusEnum {
+	return []StatusEnum{
+		StatusDraft, StatusSent, StatusAccepted,
+		StatusRejected, StatusExpired,
+	}
+}
@@ -0,0 +1,207 @@
+package quote
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+	"github.com/omareloui/odinls/internal/application/core/counter"
+	"github.com/omareloui/odinls/internal/application/core/order"
+	"github.com/omareloui/odinls/internal/errs"
+	"github.com/omareloui/odinls/internal/interfaces"
+)
+
+var (
+	ErrNotDraft         = fmt.Errorf("%w: only the draft quotes can be edited", errs.ErrInvalidTransition)
+	ErrNotAccepted      = fmt.Errorf("%w: only the accepted quotes can be converted into orders", errs.ErrInvalidTransition)
+	ErrAlreadyConverted = fmt.Errorf("%w: the quote is already converted into an order", errs.ErrInvalidTransition)
+)
+
+type quoteService struct {
+	repo      QuoteRepository
+	validator interfaces.Validator
+	sanitizer interfaces.Sanitizer
+
+	orderService   order.OrderService
+	counterService counter.CounterService
+}
+
+func NewQuoteService(repo QuoteRepository, orderService order.OrderService, counterService counter.CounterService, validator interfaces.Validator, sanitizer interfaces.Sanitizer) *quoteService {
+	return &quoteService{
+		repo:           repo,
+		validator:      validator,
+		sanitizer:      sanitizer,
+		orderService:   orderService,
+		counterService: counterService,
+	}
+}
+
+func (s *quoteService) GetQuotes(claims *jwtadapter.AccessClaims, options ...RetrieveOptsFunc) ([]Quote, error) {
+	if claims == nil || !claims.Role.IsModerator() {
+		return nil, errs.ErrForbidden
+	}
+
+	if err := s.expireQuotes(); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetQuotes(options...)
+}
+
+func (s *quoteService) GetQuoteByID(claims *jwtadapter.AccessClaims, id string, options ...RetrieveOptsFunc) (*Quote, error) {
+	if claims == nil || !claims.Role.IsModerator() {
+		return nil, errs.ErrForbidden
+	}
+
+	if err := s.expireQuotes(); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetQuoteByID(id, options...)
+}
+
+// CreateQuote drafts the quote with the current prices of the client's tier,
+// they're kept as they are till the quote gets converted.
+func (s *quoteService) CreateQuote(claims *jwtadapter.AccessClaims, q *Quote, options ...RetrieveOptsFunc) (*Quote, error) {
+	if claims == nil || !claims.Role.IsAdmin() || !claims.IsCraftsman() {
+		return nil, errs.ErrForbidden
+	}
+
+	if err := s.orderService.PriceItems(claims, q.ClientID, q.Items); err != nil {
+		return nil, err
+	}
+
+	q.Status = StatusDraft
+	q.OrderID = ""
+	q.SentOn, q.ResolvedOn = time.Time{}, time.Time{}
+
+	err := s.sanitizer.SanitizeStruct(q)
+	if err != nil {
+		return nil, errs.ErrSanitizer
+	}
+
+	if err := s.validator.Validate(q); err != nil {
+		return nil, err
+	}
+
+	num, err := s.counterService.AddOneToQuote(claims)
+	if err != nil {
+		return nil, err
+	}
+	q.Number = num
+
+	return s.repo.CreateQuote(q, options...)
+}
+
+// UpdateQuoteByID updates a draft quote, its items get priced again.
+func (s *quoteService) UpdateQuoteByID(claims *jwtadapter.AccessClaims, id string, uq *Quote, options ...RetrieveOptsFunc) (*Quote, error) {
+	if claims == nil || !claims.Role.IsAdmin() || !claims.IsCraftsman() {
+		return nil, errs.ErrForbidden
+	}
+
+	q, err := s.repo.GetQuoteByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.Status != StatusDraft {
+		return nil, ErrNotDraft
+	}
+
+	if err := s.orderService.PriceItems(claims, uq.ClientID, uq.Items); err != nil {
+		return nil, err
+	}
+
+	uq.Number = q.Number
+	uq.Status = q.Status
+	uq.OrderID = ""
+	uq.SentOn, uq.ResolvedOn = time.Time{}, time.Time{}
+
+	err = s.sanitizer.SanitizeStruct(uq)
+	if err != nil {
+		return nil, errs.ErrSanitizer
+	}
+
+	if err := s.validator.Validate(uq); err != nil {
+		return nil, err
+	}
+
+	return s.repo.UpdateQuoteByID(id, uq, options...)
+}
+
+func (s *quoteService) TransitionQuoteStatus(claims *jwtadapter.AccessClaims, id string, to StatusEnum, options ...RetrieveOptsFunc) (*Quote, error) {
+	if claims == nil || !claims.Role.IsAdmin() {
+		return nil, errs.ErrForbidden
+	}
+
+	q, err := s.repo.GetQuoteByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The quotes expire on their own.
+	if to == StatusExpired {
+		return nil, &StatusTransitionError{From: q.Status, To: to}
+	}
+
+	if err := q.TransitionTo(to, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return s.repo.UpdateQuoteByID(id, q, options...)
+}
+
+// ConvertQuote creates an order from the accepted quote with the prices it
+// was quoted with, even if the variants' prices changed since.
+func (s *quoteService) ConvertQuote(claims *jwtadapter.AccessClaims, id string) (*order.Order, error) {
+	if claims == nil || !claims.Role.IsAdmin() || !claims.IsCraftsman() {
+		return nil, errs.ErrForbidden
+	}
+
+	q, err := s.repo.GetQuoteByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.IsConverted() {
+		return nil, ErrAlreadyConverted
+	}
+	if q.Status != StatusAccepted {
+		return nil, ErrNotAccepted
+	}
+
+	items := make([]order.Item, len(q.Items))
+	for i, item := range q.Items {
+		items[i] = order.Item{
+			CustomUnitPrice: item.CustomUnitPrice,
+			Quantity:        item.Quantity,
+			Snapshot:        item.Snapshot,
+			Custom:          item.Custom,
+		}
+	}
+
+	// The quote is only set to the order if it isn't converted already, so
+	// converting it twice at once leaves one order only.
+	claim := func(orderID string) error {
+		_, err := s.repo.SetQuoteOrder(id, orderID)
+		if errors.Is(err, errs.ErrDocumentNotFound) {
+			return ErrAlreadyConverted
+		}
+		return err
+	}
+
+	return s.orderService.CreateQuotedOrder(claims, &order.Order{
+		ClientID:    q.ClientID,
+		Status:      order.StatusPendingConfirmation,
+		Items:       items,
+		PriceAddons: q.PriceAddons,
+		Note:        q.Note,
+	}, claim)
+}
+
+// expireQuotes moves the quotes that expired before today to expired.
+func (s *quoteService) expireQuotes() error {
+	y, m, d := time.Now().Date()
+	return s.repo.ExpireQuotes(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+}
@@ -0,0 +1,70 @@
+// Package quote is meant for the prices given to the clients before they
+// commit, an accepted quote gets converted into an order.
+package quote
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/omareloui/odinls/internal/application/core/client"
+	"github.com/omareloui/odinls/internal/application/core/order"
+)
+
+type Quote struct {
+	ID     string `json:"id" bson:"_id,omitempty"`
+	Number uint   `json:"number" bson:"number,omitempty"`
+
+	ClientID string     `json:"client_id" bson:"client" validate:"required,mongodb"`
+	Status   StatusEnum `json:"status" bson:"status"`
+
+	Items       []order.Item       `json:"items" bson:"items" validate:"required,min=1,dive"`
+	PriceAddons []order.PriceAddon `json:"price_addons" bson:"price_addons,omitempty" validate:"dive"`
+
+	Note string `json:"note" bson:"note,omitempty"`
+
+	// ExpiresAt is the last day the quoted prices are valid on.
+	ExpiresAt  time.Time `json:"expires_at" bson:"expires_at" validate:"required"`
+	SentOn     time.Time `json:"sent_on,omitzero" bson:"sent_on,omitempty"`
+	ResolvedOn time.Time `json:"resolved_on,omitzero" bson:"resolved_on,omitempty"`
+
+	// OrderID is the order the quote got converted into.
+	OrderID string `json:"order_id,omitzero" bson:"order,omitempty"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+
+	Client *client.Client `json:"client" bson:"populated_client,omitempty"`
+}
+
+func (q *Quote) NumberView() string {
+	return fmt.Sprintf("Q-%04d", q.Number)
+}
+
+// order is the quote as an order, to price it the same way the orders are.
+func (q *Quote) order() *order.Order {
+	return &order.Order{Items: q.Items, PriceAddons: q.PriceAddons}
+}
+
+func (q *Quote) Subtotal() float64 {
+	return q.order().Subtotal()
+}
+
+func (q *Quote) AppliedPriceAddons() []order.AppliedPriceAddon {
+	return q.order().AppliedPriceAddons()
+}
+
+func (q *Quote) TotalPrice() float64 {
+	return q.order().TotalPrice()
+}
+
+// IsExpired tells if the quote's prices aren't valid anymore, they're valid
+// till the end of the day it expires at.
+func (q *Quote) IsExpired(now time.Time) bool {
+	y, m, d := q.ExpiresAt.Date()
+	return now.After(time.Date(y, m, d, 23, 59, 59, 0, q.ExpiresAt.Location()))
+}
+
+// IsConverted tells if the quote got converted into an order.
+func (q *Quote) IsConverted() bool {
+	return q.OrderID != ""
+}
@@ -0,0 +1,20 @@
+package quote
+
+type (
+	RetrieveOptsFunc func(*RetrieveOpts)
+	RetrieveOpts     struct {
+		PopulateClient bool
+	}
+)
+
+func WithPopulatedClient(opts *RetrieveOpts) {
+	opts.PopulateClient = true
+}
+
+func ParseRetrieveOpts(funcs ...RetrieveOptsFunc) *RetrieveOpts {
+	o := &RetrieveOpts{}
+	for _, fun := range funcs {
+		fun(o)
+	}
+	return o
+}
@@ -0,0 +1,14 @@
+package quote
+
+import "time"
+
+type QuoteRepository interface {
+	GetQuotes(opts ...RetrieveOptsFunc) ([]Quote, error)
+	GetQuoteByID(id string, opts ...RetrieveOptsFunc) (*Quote, error)
+	CreateQuote(q *Quote, opts ...RetrieveOptsFunc) (*Quote, error)
+	UpdateQuoteByID(id string, q *Quote, opts ...RetrieveOptsFunc) (*Quote, error)
+	// ExpireQuotes moves the drafted and sent quotes that expired before the
+	// given time to expired.
+	ExpireQuotes(before time.Time) error
+	SetQuoteOrder(id, orderID string, opts ...RetrieveOptsFunc) (*Quote, error)
+}
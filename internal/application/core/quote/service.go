@@ -0,0 +1,15 @@
+package quote
+
+import (
+	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+	"github.com/omareloui/odinls/internal/application/core/order"
+)
+
+type QuoteService interface {
+	GetQuotes(claims *jwtadapter.AccessClaims, opts ...RetrieveOptsFunc) ([]Quote, error)
+	GetQuoteByID(claims *jwtadapter.AccessClaims, id string, opts ...RetrieveOptsFunc) (*Quote, error)
+	CreateQuote(claims *jwtadapter.AccessClaims, q *Quote, opts ...RetrieveOptsFunc) (*Quote, error)
+	UpdateQuoteByID(claims *jwtadapter.AccessClaims, id string, q *Quote, opts ...RetrieveOptsFunc) (*Quote, error)
+	TransitionQuoteStatus(claims *jwtadapter.AccessClaims, id string, to StatusEnum, opts ...RetrieveOptsFunc) (*Quote, error)
+	ConvertQuote(claims *jwtadapter.AccessClaims, id string) (*order.Order, error)
+}
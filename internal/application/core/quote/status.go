@@ -0,0 +1,82 @@
+package quote
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/omareloui/odinls/internal/errs"
+)
+
+// statusTransitions is the quote lifecycle. The expired status is reached once
+// the quote's expiry date passes.
+var statusTransitions = map[StatusEnum][]StatusEnum{
+	StatusDraft:    {StatusSent, StatusExpired},
+	StatusSent:     {StatusAccepted, StatusRejected, StatusExpired},
+	StatusAccepted: {},
+	StatusRejected: {},
+	StatusExpired:  {},
+}
+
+type StatusTransitionError struct {
+	From   StatusEnum
+	To     StatusEnum
+	Reason string
+}
+
+func (e *StatusTransitionError) Error() string {
+	msg := fmt.Sprintf("can't move the quote from %q to %q", e.From.View(), e.To.View())
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+func (e *StatusTransitionError) Unwrap() error {
+	return errs.ErrInvalidTransition
+}
+
+// NextStatuses are the statuses the quote can be moved to by hand.
+func (s StatusEnum) NextStatuses() []StatusEnum {
+	return slices.DeleteFunc(slices.Clone(statusTransitions[s]), func(to StatusEnum) bool {
+		return to == StatusExpired
+	})
+}
+
+func (s StatusEnum) CanMoveTo(to StatusEnum) bool {
+	return slices.Contains(statusTransitions[s], to)
+}
+
+func (s StatusEnum) IsFinal() bool {
+	next, ok := statusTransitions[s]
+	return ok && len(next) == 0
+}
+
+// TransitionTo moves the quote to the given status if the move is allowed and
+// stamps the matching date. An expired quote can't be sent nor accepted.
+func (q *Quote) TransitionTo(to StatusEnum, at time.Time) error {
+	from := q.Status
+	if from == "" {
+		from = StatusDraft
+	}
+
+	if !from.CanMoveTo(to) {
+		return &StatusTransitionError{From: from, To: to}
+	}
+
+	if (to == StatusSent || to == StatusAccepted) && q.IsExpired(at) {
+		return &StatusTransitionError{From: from, To: to,
+			Reason: fmt.Sprintf("it expired on %s", q.ExpiresAt.Format(time.DateOnly))}
+	}
+
+	q.Status = to
+
+	switch to {
+	case StatusSent:
+		q.SentOn = at
+	case StatusAccepted, StatusRejected, StatusExpired:
+		q.ResolvedOn = at
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package reorder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+	"github.com/omareloui/odinls/internal/application/core/material"
+	"github.com/omareloui/odinls/internal/application/core/supplier"
+	"github.com/omareloui/odinls/internal/errs"
+)
+
+const digestSubject = "Materials to reorder"
+
+type reorderService struct {
+	repo     ReorderRepository
+	notifier Notifier
+
+	// maxLeadDays is the most days to wait for a supplier's delivery.
+	maxLeadDays int
+}
+
+func NewReorderService(repo ReorderRepository, notifier Notifier, maxLeadDays int) *reorderService {
+	return &reorderService{
+		repo:        repo,
+		notifier:    notifier,
+		maxLeadDays: maxLeadDays,
+	}
+}
+
+func (s *reorderService) GetSuggestions(claims *jwtadapter.AccessClaims) ([]SupplierGroup, error) {
+	if claims == nil || !claims.Role.IsModerator() {
+		return nil, errs.ErrForbidden
+	}
+
+	return s.suggestions()
+}
+
+// SendDigest notifies with the materials to reorder, it doesn't send anything
+// if there's nothing to reorder. It's meant to run daily without a user, so it
+// doesn't take claims.
+func (s *reorderService) SendDigest() error {
+	groups, err := s.suggestions()
+	if err != nil {
+		return err
+	}
+
+	if len(groups) == 0 {
+		return nil
+	}
+
+	return s.notifier.Notify(digestSubject, digestBody(groups))
+}
+
+func (s *reorderService) suggestions() ([]SupplierGroup, error) {
+	mats, err := s.repo.GetMaterials(material.WithPopulatedSupplier)
+	if err != nil {
+		return nil, err
+	}
+
+	offers, err := s.offersByMaterial()
+	if err != nil {
+		return nil, err
+	}
+
+	groups := []SupplierGroup{}
+	groupsIdx := map[string]int{}
+
+	for i := range mats {
+		mat := &mats[i]
+		if !needsReorder(mat) {
+			continue
+		}
+
+		// The material's own supplier is the fallback if no supplier quoted it.
+		supplierID, sup := mat.SupplierID, mat.Supplier
+		suggestion := Suggestion{
+			Material:  mat,
+			Quantity:  suggestedQuantity(mat),
+			UnitPrice: mat.PricePerUnit,
+		}
+		if offer, ok := bestOffer(offers[mat.ID], s.maxLeadDays); ok {
+			supplierID, sup = offer.Supplier.ID, offer.Supplier
+			suggestion.Quantity = max(suggestion.Quantity, offer.Entry.MinOrder)
+			suggestion.UnitPrice = offer.Entry.Price
+		}
+
+		idx, ok := groupsIdx[supplierID]
+		if !ok {
+			idx = len(groups)
+			groupsIdx[supplierID] = idx
+			groups = append(groups, SupplierGroup{SupplierID: supplierID, Supplier: sup})
+		}
+
+		groups[idx].Suggestions = append(groups[idx].Suggestions, suggestion)
+	}
+
+	return groups, nil
+}
+
+// offersByMaterial gets the suppliers' quotes of each material, sorted from
+// the cheapest.
+func (s *reorderService) offersByMaterial() (map[string][]supplier.Offer, error) {
+	sups, err := s.repo.GetSuppliers()
+	if err != nil {
+		return nil, err
+	}
+
+	offers := map[string][]supplier.Offer{}
+	for i := range sups {
+		for _, entry := range sups[i].PriceList {
+			offers[entry.MaterialID] = append(offers[entry.MaterialID], supplier.Offer{Supplier: &sups[i], Entry: entry})
+		}
+	}
+
+	for _, materialOffers := range offers {
+		supplier.SortOffers(materialOffers)
+	}
+
+	return offers, nil
+}
+
+func digestBody(groups []SupplierGroup) string {
+	var b strings.Builder
+	for _, group := range groups {
+		fmt.Fprintf(&b, "%s:\n", group.SupplierName())
+		for _, suggestion := range group.Suggestions {
+			mat := suggestion.Material
+			fmt.Fprintf(&b, "- %s: %s %s at %s (%s available, reorder level %s)\n", mat.Name,
+				formatFloat(suggestion.Quantity), mat.Unit, formatFloat(suggestion.UnitPrice),
+				formatFloat(mat.Available()), formatFloat(mat.ReorderLevel))
+		}
+	}
+	return b.String()
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,67 @@
+// Package reorder finds the materials that are running low and suggests how
+// much of them to purchase from each supplier.
+package reorder
+
+import (
+	"github.com/omareloui/odinls/internal/application/core/material"
+	"github.com/omareloui/odinls/internal/application/core/supplier"
+)
+
+// Suggestion is a material at or below its reorder level and the quantity to
+// purchase of it. The unit price is the supplier's quote, or the material's
+// price if the supplier didn't quote it.
+type Suggestion struct {
+	Material  *material.Material
+	Quantity  float64
+	UnitPrice float64
+}
+
+// SupplierGroup is the suggestions of the materials bought from the same
+// supplier, so they can be ordered together.
+type SupplierGroup struct {
+	SupplierID  string
+	Supplier    *supplier.Supplier
+	Suggestions []Suggestion
+}
+
+func (g SupplierGroup) SupplierName() string {
+	if g.Supplier != nil {
+		return g.Supplier.Name
+	}
+	return g.SupplierID
+}
+
+// needsReorder reports if the material's available quantity is at or below
+// its reorder level, or if the open orders need more than what's on hand.
+func needsReorder(mat *material.Material) bool {
+	if mat.Available() < 0 {
+		return true
+	}
+	return mat.ReorderLevel > 0 && mat.Available() <= mat.ReorderLevel
+}
+
+// suggestedQuantity is the material's reorder quantity, or what brings its
+// available quantity back to the reorder level if that's more.
+func suggestedQuantity(mat *material.Material) float64 {
+	return max(mat.ReorderQuantity, mat.ReorderLevel-mat.Available())
+}
+
+// bestOffer is the cheapest of the offers delivered within the max lead days,
+// or the fastest to deliver if none of them is. The offers are expected to be
+// sorted from the cheapest.
+func bestOffer(offers []supplier.Offer, maxLeadDays int) (supplier.Offer, bool) {
+	if len(offers) == 0 {
+		return supplier.Offer{}, false
+	}
+
+	fastest := offers[0]
+	for _, offer := range offers {
+		if offer.Supplier.LeadTimeDays <= maxLeadDays {
+			return offer, true
+		}
+		if offer.Supplier.LeadTimeDays < fastest.Supplier.LeadTimeDays {
+			fastest = offer
+		}
+	}
+	return fastest, true
+}
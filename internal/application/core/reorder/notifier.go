@@ -0,0 +1,7 @@
+package reorder
+
+// Notifier sends the reorder digest, it can be a log, an email, or a chat
+// message.
+type Notifier interface {
+	Notify(subject, body string) error
+}
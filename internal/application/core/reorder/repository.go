@@ -0,0 +1,11 @@
+package reorder
+
+import (
+	"github.com/omareloui/odinls/internal/application/core/material"
+	"github.com/omareloui/odinls/internal/application/core/supplier"
+)
+
+type ReorderRepository interface {
+	GetMaterials(opts ...material.RetrieveOptsFunc) ([]material.Material, error)
+	GetSuppliers() ([]supplier.Supplier, error)
+}
@@ -0,0 +1,8 @@
+package reorder
+
+import jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+
+type ReorderService interface {
+	GetSuggestions(claims *jwtadapter.AccessClaims) ([]SupplierGroup, error)
+	SendDigest() error
+}
@@ -0,0 +1,52 @@
+package stock
+
+type ReasonEnum string
+
+const (
+	ReasonPurchase    ReasonEnum = "PURCHASE"
+	ReasonConsumption ReasonEnum = "CONSUMPTION"
+	ReasonAdjustment  ReasonEnum = "ADJUSTMENT"
+	ReasonWaste       ReasonEnum = "WASTE"
+	ReasonReturn      ReasonEnum = "RETURN"
+)
+
+func (r ReasonEnum) View() string {
+	v := map[ReasonEnum]string{
+		ReasonPurchase:    "Purchase",
+		ReasonConsumption: "Consumption",
+		ReasonAdjustment:  "Adjustment",
+		ReasonWaste:       "Waste",
+		ReasonReturn:      "Return",
+	}[r]
+	if v == "" {
+		return ReasonAdjustment.View()
+	}
+	return v
+}
+
+func ReasonsEnums() []ReasonEnum {
+	return []ReasonEnum{
+		ReasonPurchase, ReasonConsumption,
+		ReasonAdjustment, ReasonWaste, ReasonReturn,
+	}
+}
+
+// sign is the direction the reason moves the stock in, zero means it can go
+// either way.
+func (r ReasonEnum) sign() float64 {
+	switch r {
+	case ReasonPurchase, ReasonReturn:
+		return 1
+	case ReasonConsumption, ReasonWaste:
+		return -1
+	}
+	return 0
+}
+
+type ReservationStatusEnum string
+
+const (
+	ReservationActive   ReservationStatusEnum = "ACTIVE"
+	ReservationConsumed ReservationStatusEnum = "CONSUMED"
+	ReservationReleased ReservationStatusEnum = "RELEASED"
+)
@@ -0,0 +1,118 @@
+package stock
+
+import (
+	"slices"
+
+	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+	"github.com/omareloui/odinls/internal/application/core/material"
+	"github.com/omareloui/odinls/internal/application/core/product"
+	"github.com/omareloui/odinls/internal/errs"
+	"github.com/omareloui/odinls/internal/interfaces"
+)
+
+const (
+	openingBalanceNote = "Opening balance"
+	scrapWasteNote     = "Left of the hide after its scrap"
+)
+
+type stockService struct {
+	repo            StockRepository
+	validator       interfaces.Validator
+	sanitizer       interfaces.Sanitizer
+	materialService material.MaterialService
+	productService  product.ProductService
+}
+
+func NewStockService(repo StockRepository, materialService material.MaterialService, productService product.ProductService, validator interfaces.Validator, sanitizer interfaces.Sanitizer) *stockService {
+	return &stockService{
+		repo:            repo,
+		validator:       validator,
+		sanitizer:       sanitizer,
+		materialService: materialService,
+		productService:  productService,
+	}
+}
+
+func (s *stockService) GetMaterialMovements(claims *jwtadapter.AccessClaims, materialID string) ([]Movement, error) {
+	if claims == nil || !claims.Role.IsModerator() {
+		return nil, errs.ErrForbidden
+	}
+
+	return s.repo.GetMovementsByMaterialID(materialID)
+}
+
+func (s *stockService) RecordMovement(claims *jwtadapter.AccessClaims, mv *Movement) (*Movement, error) {
+	if claims == nil || !(claims.Role.IsAdmin() || claims.IsCraftsman()) {
+		return nil, errs.ErrForbidden
+	}
+
+	err := s.sanitizer.SanitizeStruct(mv)
+	if err != nil {
+		return nil, errs.ErrSanitizer
+	}
+
+	if err := s.validator.Validate(mv); err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(ReasonsEnums(), mv.Reason) {
+		return nil, errs.ErrInvalidEnum
+	}
+
+	mat, err := s.materialService.GetMaterialByID(claims, mv.MaterialID)
+	if err != nil {
+		return nil, err
+	}
+
+	mv.normalizeSign()
+	mv.Unit = mat.Unit
+	mv.UserID = claims.ID
+
+	return s.repo.AddMovement(mv)
+}
+
+// RecordOpeningBalance records the quantity a material was created with as
+// its first movement.
+func (s *stockService) RecordOpeningBalance(claims *jwtadapter.AccessClaims, mat *material.Material) error {
+	if mat.QuantityOnHand == 0 {
+		return nil
+	}
+
+	_, err := s.repo.InsertMovement(&Movement{
+		MaterialID:   mat.ID,
+		Quantity:     mat.QuantityOnHand,
+		Unit:         mat.Unit,
+		Reason:       ReasonAdjustment,
+		Note:         openingBalanceNote,
+		UserID:       claims.ID,
+		BalanceAfter: mat.QuantityOnHand,
+	})
+	return err
+}
+
+// RecordHideScrap records the usable scrap left of a hide, and takes the rest
+// of the hide out of the stock as waste.
+func (s *stockService) RecordHideScrap(claims *jwtadapter.AccessClaims, materialID, hideID string, area float64) (*material.Hide, error) {
+	scrap, waste, err := s.materialService.RecordScrap(claims, materialID, hideID, area)
+	if err != nil {
+		return nil, err
+	}
+	if waste <= 0 {
+		return scrap, nil
+	}
+
+	_, err = s.repo.AddMovement(&Movement{
+		MaterialID: materialID,
+		Quantity:   -waste,
+		Unit:       material.UnitFt2,
+		Reason:     ReasonWaste,
+		Note:       scrapWasteNote,
+		HideID:     hideID,
+		UserID:     claims.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return scrap, nil
+}
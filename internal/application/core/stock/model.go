@@ -0,0 +1,45 @@
+// Package stock is the ledger of the materials' stock movements, a material's
+// quantity on hand is the running balance of its movements.
+package stock
+
+import (
+	"math"
+	"time"
+
+	"github.com/omareloui/odinls/internal/application/core/material"
+)
+
+type Movement struct {
+	ID string `json:"id" bson:"_id,omitempty" formfield:"-"`
+
+	MaterialID string `json:"material_id" bson:"material" formfield:"-" validate:"required,mongodb"`
+
+	// Quantity is in the material's unit, it's negative for what gets taken
+	// out of the stock.
+	Quantity float64       `json:"quantity" bson:"quantity" formfield:"quantity" validate:"required"`
+	Unit     material.Unit `json:"unit" bson:"unit" formfield:"-"`
+
+	Reason ReasonEnum `json:"reason" bson:"reason" formfield:"reason" conform:"trim,upper" validate:"required"`
+
+	// Reference is the ID of the order or the purchase behind the movement.
+	Reference string `json:"reference" bson:"reference,omitempty" formfield:"reference" conform:"trim"`
+	Note      string `json:"note" bson:"note,omitempty" formfield:"note" conform:"trim"`
+
+	// HideID is the leather hide the quantity was cut from, if it's known.
+	HideID string `json:"hide_id" bson:"hide,omitempty" formfield:"-"`
+
+	UserID string `json:"user_id" bson:"user,omitempty" formfield:"-"`
+
+	BalanceAfter float64 `json:"balance_after" bson:"balance_after" formfield:"-"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at" formfield:"-"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at" formfield:"-"`
+}
+
+// normalizeSign makes the quantity's sign match the reason's direction, so a
+// consumption of 5 is recorded as -5.
+func (m *Movement) normalizeSign() {
+	if sign := m.Reason.sign(); sign != 0 {
+		m.Quantity = sign * math.Abs(m.Quantity)
+	}
+}
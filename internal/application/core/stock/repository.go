@@ -0,0 +1,18 @@
+package stock
+
+type StockRepository interface {
+	GetMovementsByMaterialID(materialID string) ([]Movement, error)
+	// AddMovement applies the movement to the material's quantity on hand and
+	// records it.
+	AddMovement(mv *Movement) (*Movement, error)
+	// InsertMovement records the movement without touching the material.
+	InsertMovement(mv *Movement) (*Movement, error)
+
+	GetActiveReservations(orderID, itemID string) ([]Reservation, error)
+	// AddReservation adds the reservation to the material's reserved quantity
+	// and records it.
+	AddReservation(res *Reservation) (*Reservation, error)
+	// CloseReservation moves an active reservation to the given status and
+	// takes it out of the material's reserved quantity.
+	CloseReservation(res *Reservation, status ReservationStatusEnum) error
+}
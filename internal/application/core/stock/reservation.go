@@ -0,0 +1,19 @@
+package stock
+
+import "time"
+
+// Reservation holds a material's quantity for an order's item from the moment
+// the order is confirmed until the item is done or the order gets canceled.
+type Reservation struct {
+	ID string `json:"id" bson:"_id,omitempty"`
+
+	OrderID    string `json:"order_id" bson:"order"`
+	ItemID     string `json:"item_id" bson:"item"`
+	MaterialID string `json:"material_id" bson:"material"`
+
+	Quantity float64               `json:"quantity" bson:"quantity"`
+	Status   ReservationStatusEnum `json:"status" bson:"status"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
@@ -0,0 +1,287 @@
+package stock
+
+import (
+	"errors"
+	"slices"
+
+	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+	"github.com/omareloui/odinls/internal/application/core/material"
+	"github.com/omareloui/odinls/internal/application/core/order"
+	"github.com/omareloui/odinls/internal/application/core/product"
+	"github.com/omareloui/odinls/internal/errs"
+)
+
+// orderRequirements returns the materials each of the order's items needs,
+// as reservations that aren't saved yet.
+func (s *stockService) orderRequirements(claims *jwtadapter.AccessClaims, ord *order.Order) ([]Reservation, error) {
+	reqs := []Reservation{}
+
+	for _, item := range ord.Items {
+		usages, err := s.itemMaterialUsage(claims, &item)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, usage := range usages {
+			if usage.Quantity <= 0 {
+				continue
+			}
+			reqs = append(reqs, Reservation{
+				OrderID:    ord.ID,
+				ItemID:     item.ID,
+				MaterialID: usage.MaterialID,
+				Quantity:   usage.Quantity * float64(item.Quantity),
+				Status:     ReservationActive,
+			})
+		}
+	}
+
+	return reqs, nil
+}
+
+// itemMaterialUsage is the materials one of the item takes, the custom items
+// carry their own.
+func (s *stockService) itemMaterialUsage(claims *jwtadapter.AccessClaims, item *order.Item) ([]product.MaterialUsage, error) {
+	if item.IsCustom() {
+		return item.Custom.MaterialUsage, nil
+	}
+
+	prod, err := s.productService.GetProductByVariantID(claims, item.Snapshot.VariantID)
+	if errors.Is(err, errs.ErrDocumentNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	idx := slices.IndexFunc(prod.Variants, func(v product.Variant) bool {
+		return v.ID == item.Snapshot.VariantID
+	})
+	if idx == -1 {
+		return nil, nil
+	}
+
+	return prod.Variants[idx].MaterialUsage, nil
+}
+
+// GetOrderShortages lists the materials that would have a negative available
+// quantity if the order gets confirmed.
+func (s *stockService) GetOrderShortages(claims *jwtadapter.AccessClaims, ord *order.Order) ([]material.Shortage, error) {
+	if claims == nil || !claims.Role.IsModerator() {
+		return nil, errs.ErrForbidden
+	}
+
+	reqs, err := s.orderRequirements(claims, ord)
+	if err != nil {
+		return nil, err
+	}
+
+	required := map[string]float64{}
+	materialsIDs := []string{}
+	for _, req := range reqs {
+		if _, ok := required[req.MaterialID]; !ok {
+			materialsIDs = append(materialsIDs, req.MaterialID)
+		}
+		required[req.MaterialID] += req.Quantity
+	}
+
+	mats, err := s.materialService.GetMaterials(claims)
+	if err != nil {
+		return nil, err
+	}
+	materials := make(map[string]*material.Material, len(mats))
+	for i := range mats {
+		materials[mats[i].ID] = &mats[i]
+	}
+
+	return material.FindShortages(materialsIDs, required, materials), nil
+}
+
+// HandleOrderStatus reserves the order's materials when it's confirmed, and
+// releases what's left of them when it's canceled or expired.
+func (s *stockService) HandleOrderStatus(claims *jwtadapter.AccessClaims, ord *order.Order) error {
+	switch ord.Status {
+	case order.StatusConfirmed:
+		return s.reserveOrder(claims, ord)
+	case order.StatusCanceled, order.StatusExpired:
+		return s.closeReservations(claims, ord.ID, "", ReservationReleased, nil)
+	}
+	return nil
+}
+
+// HandleItemProgress consumes the item's reserved materials once it's done,
+// the leather is taken out of the hides picked for the item.
+func (s *stockService) HandleItemProgress(claims *jwtadapter.AccessClaims, ord *order.Order, itemID string) error {
+	item, ok := ord.ItemByID(itemID)
+	if !ok || item.Progress != order.ItemProgressDone {
+		return nil
+	}
+
+	hides := make([]material.Hide, 0, len(item.HideIDs))
+	for _, id := range item.HideIDs {
+		hide, err := s.materialService.GetHideByID(claims, id)
+		if errors.Is(err, errs.ErrDocumentNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		hides = append(hides, *hide)
+	}
+
+	return s.closeReservations(claims, ord.ID, itemID, ReservationConsumed, hides)
+}
+
+// HandleOrderEdit makes the order's reservations match its edited items. The
+// reservations of the removed items, and the ones that don't match their items
+// anymore, are released and the missing ones are reserved. The done items'
+// reservations are already consumed, and only the confirmed and in progress
+// orders hold reservations.
+func (s *stockService) HandleOrderEdit(claims *jwtadapter.AccessClaims, ord *order.Order) error {
+	reqs := []Reservation{}
+	if ord.Status == order.StatusConfirmed || ord.Status == order.StatusInProgress {
+		var err error
+		if reqs, err = s.orderRequirements(claims, ord); err != nil {
+			return err
+		}
+		reqs = slices.DeleteFunc(reqs, func(req Reservation) bool {
+			item, ok := ord.ItemByID(req.ItemID)
+			return ok && item.Progress == order.ItemProgressDone
+		})
+	}
+
+	existing, err := s.repo.GetActiveReservations(ord.ID, "")
+	if err != nil {
+		return err
+	}
+
+	for i := range existing {
+		res := &existing[i]
+		idx := slices.IndexFunc(reqs, func(req Reservation) bool {
+			return req.ItemID == res.ItemID && req.MaterialID == res.MaterialID && req.Quantity == res.Quantity
+		})
+		if idx != -1 {
+			reqs = slices.Delete(reqs, idx, idx+1)
+			continue
+		}
+		if err := s.repo.CloseReservation(res, ReservationReleased); err != nil {
+			return err
+		}
+	}
+
+	for i := range reqs {
+		if _, err := s.repo.AddReservation(&reqs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *stockService) reserveOrder(claims *jwtadapter.AccessClaims, ord *order.Order) error {
+	existing, err := s.repo.GetActiveReservations(ord.ID, "")
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		return nil
+	}
+
+	reqs, err := s.orderRequirements(claims, ord)
+	if err != nil {
+		return err
+	}
+
+	// The order is put back to its status if reserving fails, so what's
+	// reserved so far is released to not hold the stock for it.
+	for i := range reqs {
+		if _, err := s.repo.AddReservation(&reqs[i]); err != nil {
+			if rerr := s.closeReservations(claims, ord.ID, "", ReservationReleased, nil); rerr != nil {
+				return errors.Join(err, rerr)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// closeReservations closes the order's active reservations, or only the
+// item's if itemID is set. Consumed reservations are taken out of the stock,
+// and out of the given hides of their materials.
+func (s *stockService) closeReservations(claims *jwtadapter.AccessClaims, orderID, itemID string, status ReservationStatusEnum, hides []material.Hide) error {
+	reservations, err := s.repo.GetActiveReservations(orderID, itemID)
+	if err != nil {
+		return err
+	}
+
+	for i := range reservations {
+		res := &reservations[i]
+
+		// The stock is taken first, so if there isn't enough of it the
+		// reservation stays active and gets consumed on the next try.
+		if status == ReservationConsumed {
+			mat, err := s.materialService.GetMaterialByID(claims, res.MaterialID)
+			if err != nil {
+				return err
+			}
+
+			hide, err := s.cutFromHide(claims, hides, res)
+			if err != nil {
+				return err
+			}
+
+			mv := &Movement{
+				MaterialID: res.MaterialID,
+				Quantity:   -res.Quantity,
+				Unit:       mat.Unit,
+				Reason:     ReasonConsumption,
+				Reference:  res.OrderID,
+				UserID:     claims.ID,
+			}
+			if hide != nil {
+				mv.HideID = hide.ID
+			}
+
+			if _, err = s.repo.AddMovement(mv); err != nil {
+				if hide != nil {
+					_, _ = s.materialService.ConsumeHide(claims, hide.ID, -res.Quantity)
+				}
+				return err
+			}
+		}
+
+		if err := s.repo.CloseReservation(res, status); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cutFromHide takes the reservation's quantity out of the first of the hides
+// of its material that has enough area left. It returns a nil hide if none
+// of the hides are of the material.
+func (s *stockService) cutFromHide(claims *jwtadapter.AccessClaims, hides []material.Hide, res *Reservation) (*material.Hide, error) {
+	found := false
+	for i := range hides {
+		hide := &hides[i]
+		if hide.MaterialID != res.MaterialID {
+			continue
+		}
+		found = true
+		if hide.RemainingArea < res.Quantity {
+			continue
+		}
+
+		updated, err := s.materialService.ConsumeHide(claims, hide.ID, res.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		*hide = *updated
+		return hide, nil
+	}
+
+	if found {
+		return nil, errs.ErrInsufficientStock
+	}
+	return nil, nil
+}
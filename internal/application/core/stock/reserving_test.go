@@ -0,0 +1,249 @@
+package stock_test
+
+import (
+	"errors"
+	"testing"
+
+	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+	"github.com/omareloui/odinls/internal/application/core/material"
+	material_mock "github.com/omareloui/odinls/internal/application/core/material/mocks"
+	"github.com/omareloui/odinls/internal/application/core/order"
+	"github.com/omareloui/odinls/internal/application/core/product"
+	"github.com/omareloui/odinls/internal/application/core/stock"
+	stock_mock "github.com/omareloui/odinls/internal/application/core/stock/mocks"
+	"github.com/omareloui/odinls/internal/application/core/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var admin = &jwtadapter.AccessClaims{ID: "u1", Role: user.Admin}
+
+// customOrder is an order of two custom items, item a takes 4 of m1 and item
+// b takes 1 of m1 and 3 of m2.
+func customOrder(status order.StatusEnum) *order.Order {
+	usage := func(materialID string, quantity float64) product.MaterialUsage {
+		return product.MaterialUsage{MaterialID: materialID, Quantity: quantity}
+	}
+	return &order.Order{
+		ID:     "o1",
+		Status: status,
+		Items: []order.Item{
+			{ID: "a", Quantity: 2, Custom: &order.CustomItem{MaterialUsage: []product.MaterialUsage{usage("m1", 2)}}},
+			{ID: "b", Quantity: 1, Custom: &order.CustomItem{MaterialUsage: []product.MaterialUsage{usage("m1", 1), usage("m2", 3)}}},
+		},
+	}
+}
+
+type reservation struct {
+	ItemID     string
+	MaterialID string
+	Quantity   float64
+}
+
+type stockMocks struct {
+	repo     *stock_mock.MockStockRepository
+	material *material_mock.MockMaterialService
+
+	reserved []reservation
+	released []string
+}
+
+// newStockMocks records the reservations the service adds and the ids of the
+// ones it releases.
+func newStockMocks(t *testing.T) (*stockMocks, stock.StockService) {
+	m := &stockMocks{
+		repo:     stock_mock.NewMockStockRepository(t),
+		material: material_mock.NewMockMaterialService(t),
+	}
+	m.repo.On("AddReservation", mock.Anything).Run(func(args mock.Arguments) {
+		res := args.Get(0).(*stock.Reservation)
+		m.reserved = append(m.reserved, reservation{res.ItemID, res.MaterialID, res.Quantity})
+	}).Return(&stock.Reservation{}, nil).Maybe()
+	m.repo.On("CloseReservation", mock.Anything, stock.ReservationReleased).Run(func(args mock.Arguments) {
+		m.released = append(m.released, args.Get(0).(*stock.Reservation).ID)
+	}).Return(nil).Maybe()
+	return m, stock.NewStockService(m.repo, m.material, nil, nil, nil)
+}
+
+func TestHandleOrderStatus(t *testing.T) {
+	active := []stock.Reservation{{ID: "r1", ItemID: "a", MaterialID: "m1", Quantity: 4}}
+
+	tests := []struct {
+		name         string
+		status       order.StatusEnum
+		existing     []stock.Reservation
+		wantReserved []reservation
+		wantReleased []string
+	}{
+		{
+			"confirmed reserves the items' materials",
+			order.StatusConfirmed,
+			nil,
+			[]reservation{{"a", "m1", 4}, {"b", "m1", 1}, {"b", "m2", 3}},
+			nil,
+		},
+		{
+			"confirmed again doesn't reserve twice",
+			order.StatusConfirmed,
+			active,
+			nil,
+			nil,
+		},
+		{
+			"canceled releases what's left",
+			order.StatusCanceled,
+			active,
+			nil,
+			[]string{"r1"},
+		},
+		{
+			"expired releases what's left",
+			order.StatusExpired,
+			active,
+			nil,
+			[]string{"r1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, svc := newStockMocks(t)
+			m.repo.On("GetActiveReservations", "o1", "").Return(tt.existing, nil)
+
+			assert.NoError(t, svc.HandleOrderStatus(admin, customOrder(tt.status)))
+			assert.Equal(t, tt.wantReserved, m.reserved)
+			assert.Equal(t, tt.wantReleased, m.released)
+		})
+	}
+
+	t.Run("a failed reserving releases what got reserved", func(t *testing.T) {
+		errReserve := errors.New("reserve failure")
+		repo := stock_mock.NewMockStockRepository(t)
+		svc := stock.NewStockService(repo, material_mock.NewMockMaterialService(t), nil, nil, nil)
+
+		repo.On("GetActiveReservations", "o1", "").Return(nil, nil).Once()
+		repo.On("AddReservation", mock.Anything).Return(&stock.Reservation{}, nil).Once()
+		repo.On("AddReservation", mock.Anything).Return(nil, errReserve).Once()
+		repo.On("GetActiveReservations", "o1", "").Return(active, nil).Once()
+		repo.On("CloseReservation", &active[0], stock.ReservationReleased).Return(nil).Once()
+
+		err := svc.HandleOrderStatus(admin, customOrder(order.StatusConfirmed))
+		assert.ErrorIs(t, err, errReserve)
+	})
+}
+
+func TestHandleItemProgress(t *testing.T) {
+	reserved := []stock.Reservation{{ID: "r1", OrderID: "o1", ItemID: "a", MaterialID: "m1", Quantity: 4}}
+	hide := &material.Hide{ID: "h1", MaterialID: "m1", RemainingArea: 10}
+
+	doneOrder := func(hideIDs ...string) *order.Order {
+		ord := customOrder(order.StatusInProgress)
+		ord.Items[0].Progress = order.ItemProgressDone
+		ord.Items[0].HideIDs = hideIDs
+		return ord
+	}
+
+	t.Run("items not done keep their reservations", func(t *testing.T) {
+		_, svc := newStockMocks(t)
+		assert.NoError(t, svc.HandleItemProgress(admin, customOrder(order.StatusInProgress), "a"))
+	})
+
+	t.Run("done items consume their reservations", func(t *testing.T) {
+		m, svc := newStockMocks(t)
+		m.repo.On("GetActiveReservations", "o1", "a").Return(reserved, nil)
+		m.material.On("GetMaterialByID", admin, "m1").Return(&material.Material{ID: "m1", Unit: material.UnitM}, nil)
+		m.repo.On("AddMovement", &stock.Movement{
+			MaterialID: "m1",
+			Quantity:   -4,
+			Unit:       material.UnitM,
+			Reason:     stock.ReasonConsumption,
+			Reference:  "o1",
+			UserID:     "u1",
+		}).Return(&stock.Movement{}, nil).Once()
+		m.repo.On("CloseReservation", &reserved[0], stock.ReservationConsumed).Return(nil).Once()
+
+		assert.NoError(t, svc.HandleItemProgress(admin, doneOrder(), "a"))
+	})
+
+	t.Run("the leather is cut from the item's hides", func(t *testing.T) {
+		m, svc := newStockMocks(t)
+		m.repo.On("GetActiveReservations", "o1", "a").Return(reserved, nil)
+		m.material.On("GetHideByID", admin, "h1").Return(hide, nil)
+		m.material.On("GetMaterialByID", admin, "m1").Return(&material.Material{ID: "m1"}, nil)
+		m.material.On("ConsumeHide", admin, "h1", 4.0).Return(&material.Hide{ID: "h1", MaterialID: "m1", RemainingArea: 6}, nil).Once()
+		m.repo.On("AddMovement", mock.MatchedBy(func(mv *stock.Movement) bool {
+			return mv.HideID == "h1" && mv.Quantity == -4
+		})).Return(&stock.Movement{}, nil).Once()
+		m.repo.On("CloseReservation", &reserved[0], stock.ReservationConsumed).Return(nil).Once()
+
+		assert.NoError(t, svc.HandleItemProgress(admin, doneOrder("h1"), "a"))
+	})
+
+	t.Run("a failed movement puts the hide's area back", func(t *testing.T) {
+		errMovement := errors.New("movement failure")
+		m, svc := newStockMocks(t)
+		m.repo.On("GetActiveReservations", "o1", "a").Return(reserved, nil)
+		m.material.On("GetHideByID", admin, "h1").Return(hide, nil)
+		m.material.On("GetMaterialByID", admin, "m1").Return(&material.Material{ID: "m1"}, nil)
+		m.material.On("ConsumeHide", admin, "h1", 4.0).Return(&material.Hide{ID: "h1", MaterialID: "m1", RemainingArea: 6}, nil).Once()
+		m.material.On("ConsumeHide", admin, "h1", -4.0).Return(hide, nil).Once()
+		m.repo.On("AddMovement", mock.Anything).Return(nil, errMovement).Once()
+
+		assert.ErrorIs(t, svc.HandleItemProgress(admin, doneOrder("h1"), "a"), errMovement)
+		m.repo.AssertNotCalled(t, "CloseReservation", mock.Anything, stock.ReservationConsumed)
+	})
+}
+
+func TestHandleOrderEdit(t *testing.T) {
+	existing := []stock.Reservation{
+		{ID: "r1", ItemID: "a", MaterialID: "m1", Quantity: 4},
+		{ID: "r2", ItemID: "b", MaterialID: "m2", Quantity: 2},
+		{ID: "r3", ItemID: "c", MaterialID: "m1", Quantity: 5},
+	}
+
+	tests := []struct {
+		name         string
+		status       order.StatusEnum
+		doneItemID   string
+		wantReserved []reservation
+		wantReleased []string
+	}{
+		{
+			"changed and removed items are reserved again",
+			order.StatusConfirmed,
+			"",
+			[]reservation{{"b", "m1", 1}, {"b", "m2", 3}},
+			[]string{"r2", "r3"},
+		},
+		{
+			"done items aren't reserved again",
+			order.StatusInProgress,
+			"b",
+			nil,
+			[]string{"r2", "r3"},
+		},
+		{
+			"orders not being worked on hold no reservations",
+			order.StatusPendingConfirmation,
+			"",
+			nil,
+			[]string{"r1", "r2", "r3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, svc := newStockMocks(t)
+			m.repo.On("GetActiveReservations", "o1", "").Return(existing, nil)
+
+			ord := customOrder(tt.status)
+			if item, ok := ord.ItemByID(tt.doneItemID); ok {
+				item.Progress = order.ItemProgressDone
+			}
+
+			assert.NoError(t, svc.HandleOrderEdit(admin, ord))
+			assert.Equal(t, tt.wantReserved, m.reserved)
+			assert.Equal(t, tt.wantReleased, m.released)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package stock
+
+import (
+	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
+	"github.com/omareloui/odinls/internal/application/core/material"
+	"github.com/omareloui/odinls/internal/application/core/order"
+)
+
+type StockService interface {
+	GetMaterialMovements(claims *jwtadapter.AccessClaims, materialID string) ([]Movement, error)
+	RecordMovement(claims *jwtadapter.AccessClaims, mv *Movement) (*Movement, error)
+	RecordOpeningBalance(claims *jwtadapter.AccessClaims, mat *material.Material) error
+	RecordHideScrap(claims *jwtadapter.AccessClaims, materialID, hideID string, area float64) (*material.Hide, error)
+
+	GetOrderShortages(claims *jwtadapter.AccessClaims, ord *order.Order) ([]material.Shortage, error)
+	HandleOrderStatus(claims *jwtadapter.AccessClaims, ord *order.Order) error
+	HandleItemProgress(claims *jwtadapter.AccessClaims, ord *order.Order, itemID string) error
+	HandleOrderEdit(claims *jwtadapter.AccessClaims, ord *order.Order) error
+}
@@ -1,6 +1,9 @@
 package supplier
 
 import (
+	"slices"
+	"time"
+
 	jwtadapter "github.com/omareloui/odinls/internal/adapters/jwt"
 	"github.com/omareloui/odinls/internal/errs"
 	"github.com/omareloui/odinls/internal/interfaces"
@@ -66,5 +69,86 @@ func (s *supplierService) UpdateSupplierByID(claims *jwtadapter.AccessClaims, id
 		return nil, err
 	}
 
+	current, err := s.repo.GetSupplierByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The price list only changes through its own entries.
+	sup.PriceList = current.PriceList
+
 	return s.repo.UpdateSupplierByID(id, sup)
 }
+
+// SetPriceListEntry adds the material's quote to the supplier's price list,
+// or replaces its current one.
+func (s *supplierService) SetPriceListEntry(claims *jwtadapter.AccessClaims, id string, entry *PriceListEntry) (*Supplier, error) {
+	if claims == nil || !claims.Role.IsAdmin() {
+		return nil, errs.ErrForbidden
+	}
+
+	if err := s.sanitizer.SanitizeStruct(entry); err != nil {
+		return nil, errs.ErrSanitizer
+	}
+
+	if err := s.validator.Validate(entry); err != nil {
+		return nil, err
+	}
+
+	sup, err := s.repo.GetSupplierByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.QuotedOn.IsZero() {
+		entry.QuotedOn = time.Now()
+	}
+
+	if current, ok := sup.Quote(entry.MaterialID); ok {
+		*current = *entry
+	} else {
+		sup.PriceList = append(sup.PriceList, *entry)
+	}
+
+	return s.repo.UpdateSupplierByID(id, sup)
+}
+
+func (s *supplierService) RemovePriceListEntry(claims *jwtadapter.AccessClaims, id, materialID string) (*Supplier, error) {
+	if claims == nil || !claims.Role.IsAdmin() {
+		return nil, errs.ErrForbidden
+	}
+
+	sup, err := s.repo.GetSupplierByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	sup.PriceList = slices.DeleteFunc(sup.PriceList, func(e PriceListEntry) bool {
+		return e.MaterialID == materialID
+	})
+
+	return s.repo.UpdateSupplierByID(id, sup)
+}
+
+// GetMaterialOffers gets the suppliers' quotes for the material, sorted from
+// the cheapest.
+func (s *supplierService) GetMaterialOffers(claims *jwtadapter.AccessClaims, materialID string) ([]Offer, error) {
+	if claims == nil || !claims.Role.IsModerator() {
+		return nil, errs.ErrForbidden
+	}
+
+	sups, err := s.repo.GetSuppliersByMaterialID(materialID)
+	if err != nil {
+		return nil, err
+	}
+
+	offers := make([]Offer, 0, len(sups))
+	for i := range sups {
+		if entry, ok := sups[i].Quote(materialID); ok {
+			offers = append(offers, Offer{Supplier: &sups[i], Entry: *entry})
+		}
+	}
+	SortOffers(offers)
+
+	return offers, nil
+}
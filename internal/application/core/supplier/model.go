@@ -1,7 +1,10 @@
 // Package supplier is meant for any leatherwork suppliers
 package supplier
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 type Supplier struct {
 	ID string `json:"id" formfield:"-" bson:"_id"`
@@ -9,8 +12,64 @@ type Supplier struct {
 	Name     string `json:"name" formfield:"name" bson:"name"`
 	Location string `json:"location" formfield:"location" bson:"location,omitempty"`
 
+	Contacts     []Contact `json:"contacts" formfield:"-" bson:"contacts" validate:"dive"`
+	PaymentTerms string    `json:"payment_terms" formfield:"payment_terms" bson:"payment_terms,omitempty" conform:"trim"`
+	// LeadTimeDays is how many days the supplier typically takes to deliver
+	// after getting the order.
+	LeadTimeDays int `json:"lead_time_days" formfield:"lead_time_days" bson:"lead_time_days,omitempty" validate:"min=0"`
+
+	PriceList []PriceListEntry `json:"price_list" formfield:"-" bson:"price_list" validate:"dive"`
+
 	Tags []string `json:"tags" formfield:"tags" bson:"tags"`
 
 	CreatedAt time.Time `json:"created_at" bson:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
 }
+
+type Contact struct {
+	Name     string `json:"name" bson:"name" conform:"trim,title" validate:"required,min=2,max=255"`
+	Phone    string `json:"phone" bson:"phone,omitempty" conform:"num" validate:"omitempty,min=3,max=255"`
+	Email    string `json:"email" bson:"email,omitempty" conform:"email" validate:"omitempty,email"`
+	WhatsApp string `json:"whatsapp" bson:"whatsapp,omitempty" conform:"num" validate:"omitempty,min=3,max=255"`
+}
+
+// PriceListEntry is the supplier's quoted price for a material.
+type PriceListEntry struct {
+	MaterialID string `json:"material_id" bson:"material_id" formfield:"-" validate:"required,mongodb"`
+
+	SKU      string    `json:"sku" bson:"sku,omitempty" formfield:"sku" conform:"trim,upper" validate:"max=255"`
+	Price    float64   `json:"price" bson:"price" formfield:"price" validate:"required,gt=0"`
+	MinOrder float64   `json:"min_order" bson:"min_order,omitempty" formfield:"min_order" validate:"min=0"`
+	QuotedOn time.Time `json:"quoted_on" bson:"quoted_on" formfield:"-"`
+}
+
+// Quote is the supplier's price list entry of the material if it has one.
+func (s *Supplier) Quote(materialID string) (*PriceListEntry, bool) {
+	idx := slices.IndexFunc(s.PriceList, func(e PriceListEntry) bool {
+		return e.MaterialID == materialID
+	})
+	if idx == -1 {
+		return nil, false
+	}
+	return &s.PriceList[idx], true
+}
+
+// Offer is a supplier's quote for a material.
+type Offer struct {
+	Supplier *Supplier
+	Entry    PriceListEntry
+}
+
+// SortOffers sorts the offers from the cheapest, and the fastest to deliver
+// for the same price.
+func SortOffers(offers []Offer) {
+	slices.SortStableFunc(offers, func(a, b Offer) int {
+		if a.Entry.Price != b.Entry.Price {
+			if a.Entry.Price < b.Entry.Price {
+				return -1
+			}
+			return 1
+		}
+		return a.Supplier.LeadTimeDays - b.Supplier.LeadTimeDays
+	})
+}
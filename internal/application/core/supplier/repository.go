@@ -5,4 +5,7 @@ type SupplierRepository interface {
 	GetSupplierByID(id string) (*Supplier, error)
 	CreateSupplier(supplier *Supplier) (*Supplier, error)
 	UpdateSupplierByID(id string, supplier *Supplier) (*Supplier, error)
+	// GetSuppliersByMaterialID gets the suppliers with the material in their
+	// price lists.
+	GetSuppliersByMaterialID(materialID string) ([]Supplier, error)
 }
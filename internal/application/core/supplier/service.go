@@ -7,4 +7,8 @@ type SupplierService interface {
 	GetSupplierByID(claims *jwtadapter.AccessClaims, id string) (*Supplier, error)
 	CreateSupplier(claims *jwtadapter.AccessClaims, supplier *Supplier) (*Supplier, error)
 	UpdateSupplierByID(claims *jwtadapter.AccessClaims, id string, supplier *Supplier) (*Supplier, error)
+
+	SetPriceListEntry(claims *jwtadapter.AccessClaims, id string, entry *PriceListEntry) (*Supplier, error)
+	RemovePriceListEntry(claims *jwtadapter.AccessClaims, id, materialID string) (*Supplier, error)
+	GetMaterialOffers(claims *jwtadapter.AccessClaims, materialID string) ([]Offer, error)
 }
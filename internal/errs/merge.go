@@ -0,0 +1,5 @@
+package errs
+
+import "errors"
+
+var ErrMergeConflict = errors.New("the records can't be merged")
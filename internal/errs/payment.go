@@ -0,0 +1,5 @@
+package errs
+
+import "errors"
+
+var ErrInvalidPayment = errors.New("invalid payment")
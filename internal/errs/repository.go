@@ -9,4 +9,5 @@ var (
 	ErrInvalidFloat          = errors.New("invalid float")
 	ErrInvalidNumber         = errors.New("invalid number")
 	ErrInvalidDate           = errors.New("invalid date")
+	ErrInvalidEnum           = errors.New("invalid enum value")
 )
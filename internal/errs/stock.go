@@ -0,0 +1,5 @@
+package errs
+
+import "errors"
+
+var ErrInsufficientStock = errors.New("insufficient stock")
@@ -0,0 +1,5 @@
+package errs
+
+import "errors"
+
+var ErrInvalidTransition = errors.New("invalid state transition")
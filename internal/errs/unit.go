@@ -0,0 +1,5 @@
+package errs
+
+import "errors"
+
+var ErrIncompatibleUnits = errors.New("incompatible units")
@@ -18,10 +18,11 @@ var ErrInvalidBsonKey = errors.New("invalid bson key")
 type BsonUtils struct{}
 
 type opts struct {
-	objIDKeys     []string
-	removeKeys    []string
-	stringifyKeys []string
-	append        bson.D
+	objIDKeys         []string
+	optionalObjIDKeys []string
+	removeKeys        []string
+	stringifyKeys     []string
+	append            bson.D
 }
 
 type OptsFunc func(*opts)
@@ -53,6 +54,12 @@ func (bu *BsonUtils) MarshalBsonD(rec any, opts ...OptsFunc) (bson.D, error) {
 		}
 	}
 
+	for _, k := range o.optionalObjIDKeys {
+		if err := bu.setKeyAsObjectID(doc, k); err != nil && !errors.Is(err, ErrInvalidBsonKey) {
+			return nil, err
+		}
+	}
+
 	for _, k := range o.stringifyKeys {
 		if err := bu.setKeyAsString(doc, k); err != nil {
 			return nil, err
@@ -92,6 +99,14 @@ func WithObjectID(key string) OptsFunc {
 	}
 }
 
+// WithOptionalObjectID is WithObjectID for the keys that can be missing, like
+// the omitted empty fields.
+func WithOptionalObjectID(key string) OptsFunc {
+	return func(opts *opts) {
+		opts.optionalObjIDKeys = append(opts.optionalObjIDKeys, key)
+	}
+}
+
 func WithStringfied(key string) OptsFunc {
 	return func(opts *opts) {
 		opts.stringifyKeys = append(opts.stringifyKeys, key)
@@ -113,44 +128,56 @@ func WithFieldToRemove(key string) OptsFunc {
 	}
 }
 
+// diveAndOverride overrides the value of the dotted key, the arrays on its
+// path are dived into element by element. The elements without the key are
+// left as they are, it's ErrInvalidBsonKey if none of them has it.
 func diveAndOverride[T any, K any](doc bson.D, key string, cb func(currValue T) (K, error)) error {
-	path := strings.Split(key, ".")
+	found, err := override(doc, strings.Split(key, "."), cb)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrInvalidBsonKey
+	}
+	return nil
+}
 
+func override[T any, K any](doc bson.D, path []string, cb func(currValue T) (K, error)) (bool, error) {
 	for i, obj := range doc {
-		if len(path) > 1 && obj.Key == path[0] {
-			if subdoc, ok := obj.Value.(bson.D); ok {
-				return diveAndOverride(subdoc, strings.Join(path[1:], "."), cb)
+		if obj.Key != path[0] {
+			continue
+		}
+
+		if len(path) == 1 {
+			newValue, err := cb(obj.Value.(T))
+			if err != nil {
+				return false, err
 			}
+			doc[i].Value = newValue
+			return true, nil
 		}
 
-		if arr, ok := obj.Value.(bson.A); ok {
-			for j, v := range arr {
-				arrObj, isSubDoc := v.(bson.D)
-				if !isSubDoc {
+		switch v := obj.Value.(type) {
+		case bson.D:
+			return override(v, path[1:], cb)
+		case bson.A:
+			found := false
+			for _, el := range v {
+				subdoc, ok := el.(bson.D)
+				if !ok {
 					continue
 				}
-				for k, subObj := range arrObj {
-					if subObj.Key == key {
-						newValue, err := cb(subObj.Value.(T))
-						if err != nil {
-							return err
-						}
-						doc[i].Value.(bson.A)[j].(bson.D)[k].Value = newValue
-					}
+				f, err := override(subdoc, path[1:], cb)
+				if err != nil {
+					return false, err
 				}
+				found = found || f
 			}
+			return found, nil
 		}
-
-		if obj.Key == key {
-			newValue, err := cb(obj.Value.(T))
-			if err != nil {
-				return err
-			}
-			doc[i].Value = newValue
-			return nil
-		}
+		return false, nil
 	}
-	return ErrInvalidBsonKey
+	return false, nil
 }
 
 func (bu *BsonUtils) append(doc bson.D, e bson.E) bson.D {
@@ -0,0 +1,57 @@
+package bsonutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMarshalBsonDObjectIDs(t *testing.T) {
+	type item struct {
+		ID        string `bson:"_id"`
+		Craftsman string `bson:"craftsman,omitempty"`
+	}
+	type record struct {
+		Client string `bson:"client"`
+		Items  []item `bson:"items"`
+	}
+
+	id := primitive.NewObjectID()
+	rec := record{
+		Client: id.Hex(),
+		Items:  []item{{ID: id.Hex(), Craftsman: id.Hex()}, {ID: id.Hex()}},
+	}
+
+	tests := []struct {
+		name    string
+		opts    []OptsFunc
+		wantErr error
+	}{
+		{"key", []OptsFunc{WithObjectID("client")}, nil},
+		{"key in the array's elements", []OptsFunc{WithObjectID("items._id")}, nil},
+		{"key in some of the array's elements", []OptsFunc{WithObjectID("items.craftsman")}, nil},
+		{"missing key", []OptsFunc{WithObjectID("clients")}, ErrInvalidBsonKey},
+		{"missing optional key", []OptsFunc{WithOptionalObjectID("items.hides")}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewBsonUtils().MarshalBsonD(rec, tt.opts...)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("converts the ids", func(t *testing.T) {
+		doc, err := NewBsonUtils().MarshalBsonD(rec, WithObjectID("client"), WithOptionalObjectID("items.craftsman"))
+		if !assert.NoError(t, err) {
+			return
+		}
+		m := doc.Map()
+		assert.Equal(t, id, m["client"])
+		items := m["items"].(bson.A)
+		assert.Equal(t, id, items[0].(bson.D).Map()["craftsman"])
+		assert.Equal(t, id.Hex(), items[0].(bson.D).Map()["_id"])
+	})
+}
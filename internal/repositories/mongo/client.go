@@ -1,7 +1,16 @@
 package mongo
 
 import (
+	"time"
+
 	"github.com/omareloui/odinls/internal/application/core/client"
+	"github.com/omareloui/odinls/internal/errs"
+	"github.com/omareloui/odinls/internal/logger"
+	"github.com/omareloui/odinls/internal/repositories/mongo/bsonutils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
 )
 
 func (r *repository) GetClients() ([]client.Client, error) {
@@ -18,6 +27,13 @@ func (r *repository) GetClientByID(id string) (*client.Client, error) {
 	return GetByID[client.Client](ctx, r.clientsColl, id)
 }
 
+func (r *repository) GetClientByUserID(userID string) (*client.Client, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	return GetOne[client.Client](ctx, r.clientsColl, bson.M{"user": userID})
+}
+
 func (r *repository) CreateClient(cli *client.Client) (*client.Client, error) {
 	ctx, cancel := r.newCtx()
 	defer cancel()
@@ -31,3 +47,106 @@ func (r *repository) UpdateClientByID(id string, cli *client.Client) (*client.Cl
 
 	return UpdateStructByID[client.Client](ctx, r.clientsColl, id, cli)
 }
+
+func (r *repository) UpdateClientContactInfo(id string, contactInfo client.ContactInfo) (*client.Client, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"contact_info": contactInfo,
+			"updated_at":   time.Now(),
+		},
+	}
+
+	return UpdateByID[client.Client](ctx, r.clientsColl, id, update)
+}
+
+// SetClientUser links the client to the user, or unlinks it if the user ID is
+// empty.
+func (r *repository) SetClientUser(id, userID string) (*client.Client, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	update := bson.M{
+		"$set":   bson.M{"updated_at": time.Now()},
+		"$unset": bson.M{"user": ""},
+	}
+	if userID != "" {
+		update = bson.M{"$set": bson.M{"user": userID, "updated_at": time.Now()}}
+	}
+
+	return UpdateByID[client.Client](ctx, r.clientsColl, id, update)
+}
+
+func (r *repository) DeleteClientByID(id string) error {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	return DeleteByID(ctx, r.clientsColl, id)
+}
+
+func (r *repository) ReassignClientOrders(fromID, toID string) (int64, error) {
+	return r.reassignClient(r.ordersColl, fromID, toID)
+}
+
+func (r *repository) ReassignClientQuotes(fromID, toID string) (int64, error) {
+	return r.reassignClient(r.quotesColl, fromID, toID)
+}
+
+// reassignClient moves the collection's documents of a client to another.
+func (r *repository) reassignClient(coll *mongo.Collection, fromID, toID string) (int64, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	l := logger.FromCtx(ctx)
+
+	fromObjID, err := primitive.ObjectIDFromHex(fromID)
+	if err != nil {
+		return 0, errs.ErrInvalidID
+	}
+	toObjID, err := primitive.ObjectIDFromHex(toID)
+	if err != nil {
+		return 0, errs.ErrInvalidID
+	}
+
+	filter := bson.M{"client": fromObjID}
+	update := bson.M{"$set": bson.M{"client": toObjID, "updated_at": time.Now()}}
+
+	res, err := coll.UpdateMany(ctx, filter, update)
+	if err != nil {
+		l.Error("error reassigning the client's documents", zap.String("collection", coll.Name()), zap.Error(err), zap.String("from", fromID), zap.String("to", toID))
+		return 0, err
+	}
+
+	l.Info("reassigned the client's documents", zap.String("collection", coll.Name()), zap.String("from", fromID), zap.String("to", toID), zap.Int64("count", res.ModifiedCount))
+
+	return res.ModifiedCount, nil
+}
+
+func (r *repository) CreateClientMerge(merge *client.Merge) (*client.Merge, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	return InsertStruct(ctx, r.mergesColl, merge,
+		bsonutils.WithObjectID("survivor"),
+		bsonutils.WithObjectID("duplicate._id"),
+		bsonutils.WithObjectID("merged_by"),
+	)
+}
+
+func (r *repository) GetClientMerges(survivorID string) ([]client.Merge, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	survivorObjID, err := primitive.ObjectIDFromHex(survivorID)
+	if err != nil {
+		return nil, errs.ErrInvalidID
+	}
+
+	return PopulateAggregation[client.Merge](ctx, r.mergesColl,
+		bson.A{
+			bson.M{"$match": bson.M{"survivor": survivorObjID}},
+			bson.M{"$sort": bson.M{"created_at": -1}},
+		})
+}
@@ -0,0 +1,34 @@
+package mongo
+
+import (
+	"errors"
+
+	"github.com/omareloui/odinls/internal/application/core/costing"
+	"github.com/omareloui/odinls/internal/errs"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (r *repository) GetCostingSettings() (*costing.Settings, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	return GetOne[costing.Settings](ctx, r.costingColl, bson.M{})
+}
+
+// SaveCostingSettings updates the only settings document, or creates it if
+// it's the first time to save them.
+func (r *repository) SaveCostingSettings(settings *costing.Settings) (*costing.Settings, error) {
+	existing, err := r.GetCostingSettings()
+	if err != nil && !errors.Is(err, errs.ErrDocumentNotFound) {
+		return nil, err
+	}
+
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	if existing == nil {
+		return InsertStruct(ctx, r.costingColl, settings)
+	}
+
+	return UpdateStructByID(ctx, r.costingColl, existing.ID, settings)
+}
@@ -83,3 +83,25 @@ func (r *repository) AddOneToOrder() (uint, error) {
 
 	return cntr.OrdersNumber, nil
 }
+
+func (r *repository) AddOneToQuote() (uint, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	filter := bson.M{}
+	update := bson.M{
+		"$inc": bson.M{"quotes_number": amountToIncrement},
+	}
+
+	err := UpdateOne[counter.Counter](ctx, r.countersColl, filter, update)
+	if err != nil {
+		return 0, err
+	}
+
+	cntr, err := r.getCounter()
+	if err != nil {
+		return 0, err
+	}
+
+	return cntr.QuotesNumber, nil
+}
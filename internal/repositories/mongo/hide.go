@@ -0,0 +1,81 @@
+package mongo
+
+import (
+	"errors"
+	"time"
+
+	"github.com/omareloui/odinls/internal/application/core/material"
+	"github.com/omareloui/odinls/internal/errs"
+	"github.com/omareloui/odinls/internal/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+func (r *repository) GetHides(materialID string) ([]material.Hide, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	match := bson.M{}
+	if materialID != "" {
+		match["material"] = materialID
+	}
+
+	return PopulateAggregation[material.Hide](ctx, r.hidesColl,
+		bson.A{
+			bson.M{"$match": match},
+			bson.M{"$sort": bson.M{"created_at": -1}},
+		})
+}
+
+func (r *repository) GetHideByID(id string) (*material.Hide, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	return GetByID[material.Hide](ctx, r.hidesColl, id)
+}
+
+func (r *repository) CreateHide(hide *material.Hide) (*material.Hide, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	return InsertStruct(ctx, r.hidesColl, hide)
+}
+
+func (r *repository) ConsumeHideArea(id string, area float64) (*material.Hide, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	l := logger.FromCtx(ctx)
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errs.ErrInvalidID
+	}
+
+	// The remaining area is checked in the same operation that updates it, so
+	// two items can't be cut from the same piece of leather.
+	filter := bson.M{"_id": objID}
+	if area > 0 {
+		filter["remaining_area"] = bson.M{"$gte": area}
+	}
+	update := bson.M{
+		"$inc": bson.M{"remaining_area": -area},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+
+	hide := new(material.Hide)
+	err = r.hidesColl.FindOneAndUpdate(ctx, filter, update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(hide)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errs.ErrInsufficientStock
+		}
+		l.Error("error updating the hide's remaining area", zap.Error(err), zap.String("id", id), zap.Float64("area", area))
+		return nil, err
+	}
+
+	return hide, nil
+}
@@ -1,7 +1,11 @@
 package mongo
 
 import (
+	"time"
+
 	"github.com/omareloui/odinls/internal/application/core/material"
+	"github.com/omareloui/odinls/internal/repositories/mongo/bsonutils"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 func (r *repository) GetMaterials(options ...material.RetrieveOptsFunc) ([]material.Material, error) {
@@ -53,7 +57,13 @@ func (r *repository) UpdateMaterialByID(id string, mat *material.Material, optio
 	ctx, cancel := r.newCtx()
 	defer cancel()
 
-	m, err := UpdateStructByID(ctx, r.materialsColl, id, mat)
+	// The quantities and the lots are only changed by their own updates, so
+	// they're left out to not overwrite a concurrent change to them.
+	m, err := UpdateStructByID(ctx, r.materialsColl, id, mat,
+		bsonutils.WithFieldToRemove("quantity_on_hand"),
+		bsonutils.WithFieldToRemove("quantity_reserved"),
+		bsonutils.WithFieldToRemove("lots"),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -64,6 +74,21 @@ func (r *repository) UpdateMaterialByID(id string, mat *material.Material, optio
 	return m, nil
 }
 
+func (r *repository) AddMaterialLot(id string, lot material.Lot, price *material.PriceEntry) (*material.Material, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	set := bson.M{"updated_at": time.Now()}
+	push := bson.M{"lots": lot}
+	if price != nil {
+		set["price_per_unit"] = price.PricePerUnit
+		set["last_price_update"] = price.Date
+		push["price_history"] = price
+	}
+
+	return UpdateByID[material.Material](ctx, r.materialsColl, id, bson.M{"$set": set, "$push": push})
+}
+
 func (r *repository) populateMaterials(mats []material.Material, opts *material.RetrieveOpts) {
 	for _, material := range mats {
 		r.populateMaterial(&material, opts)
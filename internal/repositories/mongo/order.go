@@ -1,9 +1,13 @@
 package mongo
 
 import (
+	"time"
+
 	"github.com/omareloui/odinls/internal/application/core/order"
+	"github.com/omareloui/odinls/internal/errs"
 	"github.com/omareloui/odinls/internal/repositories/mongo/bsonutils"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func (r *repository) GetOrders(options ...order.RetrieveOptsFunc) ([]order.Order, error) {
@@ -15,6 +19,51 @@ func (r *repository) GetOrders(options ...order.RetrieveOptsFunc) ([]order.Order
 	return PopulateAggregation[order.Order](ctx, r.ordersColl, bson.A{}, r.orderOptsToPopulateOpts(opts)...)
 }
 
+func (r *repository) GetOrdersByStatuses(statuses []order.StatusEnum, options ...order.RetrieveOptsFunc) ([]order.Order, error) {
+	opts := order.ParseRetrieveOpts(options...)
+
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	return PopulateAggregation[order.Order](ctx, r.ordersColl,
+		bson.A{
+			bson.M{"$match": bson.M{"status": bson.M{"$in": statuses}}},
+		},
+		r.orderOptsToPopulateOpts(opts)...)
+}
+
+func (r *repository) GetOrdersByClientID(clientID string, options ...order.RetrieveOptsFunc) ([]order.Order, error) {
+	opts := order.ParseRetrieveOpts(options...)
+
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	clientObjID, err := primitive.ObjectIDFromHex(clientID)
+	if err != nil {
+		return nil, errs.ErrInvalidID
+	}
+
+	return PopulateAggregation[order.Order](ctx, r.ordersColl,
+		bson.A{
+			bson.M{"$match": bson.M{"client": clientObjID}},
+			bson.M{"$sort": bson.M{"created_at": -1}},
+		},
+		r.orderOptsToPopulateOpts(opts)...)
+}
+
+func (r *repository) GetOrdersWithInstalments(options ...order.RetrieveOptsFunc) ([]order.Order, error) {
+	opts := order.ParseRetrieveOpts(options...)
+
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	return PopulateAggregation[order.Order](ctx, r.ordersColl,
+		bson.A{
+			bson.M{"$match": bson.M{"payment_plan.instalments.0": bson.M{"$exists": true}}},
+		},
+		r.orderOptsToPopulateOpts(opts)...)
+}
+
 func (r *repository) GetOrderByID(id string, options ...order.RetrieveOptsFunc) (*order.Order, error) {
 	opts := order.ParseRetrieveOpts(options...)
 
@@ -24,17 +73,39 @@ func (r *repository) GetOrderByID(id string, options ...order.RetrieveOptsFunc)
 	return PopulateAggregationByID[order.Order](ctx, r.ordersColl, id, r.orderOptsToPopulateOpts(opts)...)
 }
 
+func (r *repository) GetOrderByRef(ref string, options ...order.RetrieveOptsFunc) (*order.Order, error) {
+	opts := order.ParseRetrieveOpts(options...)
+
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	docs, err := PopulateAggregation[order.Order](ctx, r.ordersColl,
+		bson.A{
+			bson.M{"$match": bson.M{"ref": ref}},
+		},
+		r.orderOptsToPopulateOpts(opts)...)
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return nil, errs.ErrDocumentNotFound
+	}
+
+	return &docs[0], nil
+}
+
 func (r *repository) CreateOrder(ord *order.Order, options ...order.RetrieveOptsFunc) (*order.Order, error) {
 	ctx, cancel := r.newCtx()
 	defer cancel()
 
+	setOrderItemsIDs(ord)
+
 	res, err := InsertStruct(ctx, r.ordersColl, ord,
 		bsonutils.WithObjectID("client"),
-		bsonutils.WithObjectID("craftsmen"),
 		bsonutils.WithObjectID("items._id"),
-		bsonutils.WithObjectID("items.craftsman"),
-		bsonutils.WithObjectID("items.snapshot.product"),
-		bsonutils.WithObjectID("items.snapshot.variant_id"),
+		bsonutils.WithOptionalObjectID("items.craftsman"),
+		bsonutils.WithOptionalObjectID("items.snapshot.product"),
+		bsonutils.WithOptionalObjectID("items.snapshot.variant_id"),
 	)
 	if err != nil {
 		return nil, err
@@ -43,17 +114,28 @@ func (r *repository) CreateOrder(ord *order.Order, options ...order.RetrieveOpts
 	return r.GetOrderByID(res.ID, options...)
 }
 
+func (r *repository) DeleteOrderByID(id string) error {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	return DeleteByID(ctx, r.ordersColl, id)
+}
+
 func (r *repository) UpdateOrderByID(id string, ord *order.Order, options ...order.RetrieveOptsFunc) (*order.Order, error) {
 	ctx, cancel := r.newCtx()
 	defer cancel()
 
+	setOrderItemsIDs(ord)
+
 	_, err := UpdateStructByID(ctx, r.ordersColl, id, ord,
 		bsonutils.WithObjectID("client"),
-		bsonutils.WithObjectID("craftsmen"),
 		bsonutils.WithObjectID("items._id"),
-		bsonutils.WithObjectID("items.craftsman"),
-		bsonutils.WithObjectID("items.snapshot.product"),
-		bsonutils.WithObjectID("items.snapshot.variant_id"),
+		bsonutils.WithOptionalObjectID("items.craftsman"),
+		bsonutils.WithOptionalObjectID("items.snapshot.product"),
+		bsonutils.WithOptionalObjectID("items.snapshot.variant_id"),
+		bsonutils.WithOptionalObjectID("items.custom.promoted_product"),
+		bsonutils.WithFieldToRemove("payments"),
+		bsonutils.WithFieldToRemove("payment_plan"),
 	)
 	if err != nil {
 		return nil, err
@@ -61,6 +143,200 @@ func (r *repository) UpdateOrderByID(id string, ord *order.Order, options ...ord
 	return r.GetOrderByID(id, options...)
 }
 
+func (r *repository) UpdateOrderStatusByID(id string, status order.StatusEnum, timeline order.Timeline, options ...order.RetrieveOptsFunc) (*order.Order, error) {
+	return r.updateOrderStatus(id, bson.M{"status": status, "timeline": timeline}, nil, options...)
+}
+
+// UpdateOrderStatusWaivingDeposit updates the order's status and records who
+// waived its deposit in the same update.
+func (r *repository) UpdateOrderStatusWaivingDeposit(id string, status order.StatusEnum, timeline order.Timeline, userID string, options ...order.RetrieveOptsFunc) (*order.Order, error) {
+	return r.updateOrderStatus(id, bson.M{
+		"status":                         status,
+		"timeline":                       timeline,
+		"payment_plan.deposit_waived_by": userID,
+	}, nil, options...)
+}
+
+// UpdateOrderStatusUnwaivingDeposit updates the order's status and removes its
+// deposit's waiver in the same update.
+func (r *repository) UpdateOrderStatusUnwaivingDeposit(id string, status order.StatusEnum, timeline order.Timeline, options ...order.RetrieveOptsFunc) (*order.Order, error) {
+	return r.updateOrderStatus(id,
+		bson.M{"status": status, "timeline": timeline},
+		bson.M{"payment_plan.deposit_waived_by": ""},
+		options...)
+}
+
+func (r *repository) updateOrderStatus(id string, set, unset bson.M, options ...order.RetrieveOptsFunc) (*order.Order, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	set["updated_at"] = time.Now()
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	_, err := UpdateByID[order.Order](ctx, r.ordersColl, id, update)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetOrderByID(id, options...)
+}
+
+func (r *repository) UpdateOrderItemProgress(orderID, itemID string, progress order.ItemProgressEnum, options ...order.RetrieveOptsFunc) (*order.Order, error) {
+	update := bson.M{
+		"$set": bson.M{
+			"items.$.progress": progress,
+			"updated_at":       time.Now(),
+		},
+	}
+	return r.updateOrderItem(orderID, itemID, update, options...)
+}
+
+func (r *repository) UpdateOrderItemCraftsman(orderID, itemID, craftsmanID string, options ...order.RetrieveOptsFunc) (*order.Order, error) {
+	update := bson.M{
+		"$set":   bson.M{"updated_at": time.Now()},
+		"$unset": bson.M{"items.$.craftsman": ""},
+	}
+
+	if craftsmanID != "" {
+		craftsmanObjID, err := primitive.ObjectIDFromHex(craftsmanID)
+		if err != nil {
+			return nil, errs.ErrInvalidID
+		}
+		update = bson.M{
+			"$set": bson.M{
+				"items.$.craftsman": craftsmanObjID,
+				"updated_at":        time.Now(),
+			},
+		}
+	}
+
+	return r.updateOrderItem(orderID, itemID, update, options...)
+}
+
+func (r *repository) UpdateOrderItemHides(orderID, itemID string, hideIDs []string, options ...order.RetrieveOptsFunc) (*order.Order, error) {
+	update := bson.M{
+		"$set":   bson.M{"updated_at": time.Now()},
+		"$unset": bson.M{"items.$.hides": ""},
+	}
+
+	if len(hideIDs) > 0 {
+		update = bson.M{
+			"$set": bson.M{
+				"items.$.hides": hideIDs,
+				"updated_at":    time.Now(),
+			},
+		}
+	}
+
+	return r.updateOrderItem(orderID, itemID, update, options...)
+}
+
+func (r *repository) UpdateOrderItemPromotedProduct(orderID, itemID, productID string, options ...order.RetrieveOptsFunc) (*order.Order, error) {
+	productObjID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, errs.ErrInvalidID
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"items.$.custom.promoted_product": productObjID,
+			"updated_at":                      time.Now(),
+		},
+	}
+
+	return r.updateOrderItem(orderID, itemID, update, options...)
+}
+
+func (r *repository) SetOrderPaymentPlan(orderID string, plan *order.PaymentPlan, options ...order.RetrieveOptsFunc) (*order.Order, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{"payment_plan": plan, "updated_at": time.Now()},
+	}
+	if plan == nil {
+		update = bson.M{
+			"$unset": bson.M{"payment_plan": ""},
+			"$set":   bson.M{"updated_at": time.Now()},
+		}
+	}
+
+	if _, err := UpdateByID[order.Order](ctx, r.ordersColl, orderID, update); err != nil {
+		return nil, err
+	}
+
+	return r.GetOrderByID(orderID, options...)
+}
+
+func (r *repository) AddOrderPayment(orderID string, payment *order.Payment, paymentsCount int, options ...order.RetrieveOptsFunc) (*order.Order, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	orderObjID, err := primitive.ObjectIDFromHex(orderID)
+	if err != nil {
+		return nil, errs.ErrInvalidID
+	}
+
+	bu := bsonutils.NewBsonUtils()
+	doc, err := bu.MarshalBsonD(payment, bsonutils.WithFieldToAdd("_id", primitive.NewObjectID()))
+	if err != nil {
+		return nil, err
+	}
+
+	// The payment is only added if no other payment was added since the order
+	// was read, so two payments can't take the same number.
+	filter := bson.M{
+		"_id": orderObjID,
+		"$expr": bson.M{"$eq": bson.A{
+			bson.M{"$size": bson.M{"$ifNull": bson.A{"$payments", bson.A{}}}},
+			paymentsCount,
+		}},
+	}
+	update := bson.M{
+		"$push": bson.M{"payments": doc},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	if err := UpdateOne[order.Order](ctx, r.ordersColl, filter, update); err != nil {
+		return nil, err
+	}
+
+	return r.GetOrderByID(orderID, options...)
+}
+
+func (r *repository) updateOrderItem(orderID, itemID string, update bson.M, options ...order.RetrieveOptsFunc) (*order.Order, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	orderObjID, err := primitive.ObjectIDFromHex(orderID)
+	if err != nil {
+		return nil, errs.ErrInvalidID
+	}
+	itemObjID, err := primitive.ObjectIDFromHex(itemID)
+	if err != nil {
+		return nil, errs.ErrInvalidID
+	}
+
+	filter := bson.M{"_id": orderObjID, "items._id": itemObjID}
+	if err := UpdateOne[order.Order](ctx, r.ordersColl, filter, update); err != nil {
+		return nil, err
+	}
+
+	return r.GetOrderByID(orderID, options...)
+}
+
+// setOrderItemsIDs gives the new items an id, so they can be updated on their
+// own.
+func setOrderItemsIDs(ord *order.Order) {
+	for i := range ord.Items {
+		if ord.Items[i].ID == "" {
+			ord.Items[i].ID = primitive.NewObjectID().Hex()
+		}
+	}
+}
+
 func (r *repository) orderOptsToPopulateOpts(opts *order.RetrieveOpts) []populateOpts {
 	return []populateOpts{
 		{
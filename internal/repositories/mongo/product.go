@@ -3,6 +3,7 @@ package mongo
 import (
 	"github.com/omareloui/odinls/internal/application/core/product"
 	"github.com/omareloui/odinls/internal/errs"
+	"github.com/omareloui/odinls/internal/repositories/mongo/bsonutils"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -44,15 +45,33 @@ func (r *repository) GetProductByVariantID(id string, options ...product.Retriev
 	if err != nil {
 		return nil, err
 	}
+	if len(docs) == 0 {
+		return nil, errs.ErrDocumentNotFound
+	}
 
 	return &docs[0], nil
 }
 
+func (r *repository) GetProductsByMaterialID(materialID string, options ...product.RetrieveOptsFunc) ([]product.Product, error) {
+	opts := product.ParseRetrieveOpts(options...)
+
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	return PopulateAggregation[product.Product](ctx, r.productsColl,
+		bson.A{
+			bson.M{"$match": bson.M{"variants.material_usage.material_id": materialID}},
+		},
+		r.productOptsToPopulateOpts(opts)...)
+}
+
 func (r *repository) CreateProduct(prod *product.Product, options ...product.RetrieveOptsFunc) (*product.Product, error) {
 	ctx, cancel := r.newCtx()
 	defer cancel()
 
-	doc, err := InsertStruct(ctx, r.productsColl, prod)
+	doc, err := InsertStruct(ctx, r.productsColl, prod,
+		bsonutils.WithOptionalObjectID("variants.default_craftsman"),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -64,7 +83,9 @@ func (r *repository) UpdateProductByID(id string, prod *product.Product, options
 	ctx, cancel := r.newCtx()
 	defer cancel()
 
-	doc, err := UpdateStructByID(ctx, r.productsColl, id, prod)
+	doc, err := UpdateStructByID(ctx, r.productsColl, id, prod,
+		bsonutils.WithOptionalObjectID("variants.default_craftsman"),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,125 @@
+package mongo
+
+import (
+	"time"
+
+	"github.com/omareloui/odinls/internal/application/core/purchase"
+	"github.com/omareloui/odinls/internal/errs"
+	"github.com/omareloui/odinls/internal/repositories/mongo/bsonutils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (r *repository) GetPurchaseOrders(options ...purchase.RetrieveOptsFunc) ([]purchase.PurchaseOrder, error) {
+	opts := purchase.ParseRetrieveOpts(options...)
+
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	return PopulateAggregation[purchase.PurchaseOrder](ctx, r.purchasesColl,
+		bson.A{
+			bson.M{"$sort": bson.M{"created_at": -1}},
+		},
+		r.purchaseOptsToPopulateOpts(opts)...)
+}
+
+func (r *repository) GetPurchaseOrdersBySupplierID(supplierID string, options ...purchase.RetrieveOptsFunc) ([]purchase.PurchaseOrder, error) {
+	opts := purchase.ParseRetrieveOpts(options...)
+
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	supObjID, err := primitive.ObjectIDFromHex(supplierID)
+	if err != nil {
+		return nil, errs.ErrInvalidID
+	}
+
+	return PopulateAggregation[purchase.PurchaseOrder](ctx, r.purchasesColl,
+		bson.A{
+			bson.M{"$match": bson.M{"supplier": supObjID}},
+			bson.M{"$sort": bson.M{"created_at": -1}},
+		},
+		r.purchaseOptsToPopulateOpts(opts)...)
+}
+
+func (r *repository) GetPurchaseOrderByID(id string, options ...purchase.RetrieveOptsFunc) (*purchase.PurchaseOrder, error) {
+	opts := purchase.ParseRetrieveOpts(options...)
+
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	return PopulateAggregationByID[purchase.PurchaseOrder](ctx, r.purchasesColl, id, r.purchaseOptsToPopulateOpts(opts)...)
+}
+
+func (r *repository) CreatePurchaseOrder(po *purchase.PurchaseOrder, options ...purchase.RetrieveOptsFunc) (*purchase.PurchaseOrder, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	res, err := InsertStruct(ctx, r.purchasesColl, po, bsonutils.WithObjectID("supplier"))
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetPurchaseOrderByID(res.ID, options...)
+}
+
+func (r *repository) UpdatePurchaseOrderByID(id string, po *purchase.PurchaseOrder, options ...purchase.RetrieveOptsFunc) (*purchase.PurchaseOrder, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	_, err := UpdateStructByID(ctx, r.purchasesColl, id, po, bsonutils.WithObjectID("supplier"))
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetPurchaseOrderByID(id, options...)
+}
+
+func (r *repository) UpdatePurchaseOrderReceipts(id string, po *purchase.PurchaseOrder, receiptsCount int, options ...purchase.RetrieveOptsFunc) (*purchase.PurchaseOrder, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errs.ErrInvalidID
+	}
+
+	// The receipts are only saved if no other receipts were saved since the
+	// purchase order was read, so they can't overwrite them.
+	filter := bson.M{
+		"_id": objID,
+		"$expr": bson.M{"$eq": bson.A{
+			bson.M{"$size": bson.M{"$ifNull": bson.A{"$receipts", bson.A{}}}},
+			receiptsCount,
+		}},
+	}
+
+	set := bson.M{
+		"lines":      po.Lines,
+		"receipts":   po.Receipts,
+		"status":     po.Status,
+		"updated_at": time.Now(),
+	}
+	update := bson.M{"$set": set}
+	if po.ResolvedOn.IsZero() {
+		update["$unset"] = bson.M{"resolved_on": ""}
+	} else {
+		set["resolved_on"] = po.ResolvedOn
+	}
+
+	if err := UpdateOne[purchase.PurchaseOrder](ctx, r.purchasesColl, filter, update); err != nil {
+		return nil, err
+	}
+
+	return r.GetPurchaseOrderByID(id, options...)
+}
+
+func (r *repository) purchaseOptsToPopulateOpts(opts *purchase.RetrieveOpts) []populateOpts {
+	return []populateOpts{{
+		include:      opts.PopulateSupplier,
+		from:         suppliersCollectionName,
+		foreignField: "_id",
+		localField:   "supplier",
+		as:           "populated_supplier",
+	}}
+}
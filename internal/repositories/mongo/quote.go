@@ -0,0 +1,127 @@
+package mongo
+
+import (
+	"time"
+
+	"github.com/omareloui/odinls/internal/application/core/quote"
+	"github.com/omareloui/odinls/internal/errs"
+	"github.com/omareloui/odinls/internal/logger"
+	"github.com/omareloui/odinls/internal/repositories/mongo/bsonutils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+func (r *repository) GetQuotes(options ...quote.RetrieveOptsFunc) ([]quote.Quote, error) {
+	opts := quote.ParseRetrieveOpts(options...)
+
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	return PopulateAggregation[quote.Quote](ctx, r.quotesColl,
+		bson.A{
+			bson.M{"$sort": bson.M{"created_at": -1}},
+		},
+		r.quoteOptsToPopulateOpts(opts)...)
+}
+
+func (r *repository) GetQuoteByID(id string, options ...quote.RetrieveOptsFunc) (*quote.Quote, error) {
+	opts := quote.ParseRetrieveOpts(options...)
+
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	return PopulateAggregationByID[quote.Quote](ctx, r.quotesColl, id, r.quoteOptsToPopulateOpts(opts)...)
+}
+
+func (r *repository) CreateQuote(q *quote.Quote, options ...quote.RetrieveOptsFunc) (*quote.Quote, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	res, err := InsertStruct(ctx, r.quotesColl, q,
+		bsonutils.WithObjectID("client"),
+		bsonutils.WithOptionalObjectID("items.snapshot.product"),
+		bsonutils.WithOptionalObjectID("items.snapshot.variant_id"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetQuoteByID(res.ID, options...)
+}
+
+func (r *repository) UpdateQuoteByID(id string, q *quote.Quote, options ...quote.RetrieveOptsFunc) (*quote.Quote, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	_, err := UpdateStructByID(ctx, r.quotesColl, id, q,
+		bsonutils.WithObjectID("client"),
+		bsonutils.WithOptionalObjectID("items.snapshot.product"),
+		bsonutils.WithOptionalObjectID("items.snapshot.variant_id"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetQuoteByID(id, options...)
+}
+
+func (r *repository) ExpireQuotes(before time.Time) error {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	l := logger.FromCtx(ctx)
+
+	now := time.Now()
+	filter := bson.M{
+		"status":     bson.M{"$in": bson.A{quote.StatusDraft, quote.StatusSent}},
+		"expires_at": bson.M{"$lt": before},
+	}
+	update := bson.M{"$set": bson.M{"status": quote.StatusExpired, "resolved_on": now, "updated_at": now}}
+
+	res, err := r.quotesColl.UpdateMany(ctx, filter, update)
+	if err != nil {
+		l.Error("error expiring the quotes", zap.Error(err), zap.Time("before", before))
+		return err
+	}
+
+	if res.ModifiedCount > 0 {
+		l.Info("expired the quotes", zap.Time("before", before), zap.Int64("count", res.ModifiedCount))
+	}
+
+	return nil
+}
+
+func (r *repository) SetQuoteOrder(id, orderID string, options ...quote.RetrieveOptsFunc) (*quote.Quote, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errs.ErrInvalidID
+	}
+	orderObjID, err := primitive.ObjectIDFromHex(orderID)
+	if err != nil {
+		return nil, errs.ErrInvalidID
+	}
+
+	// Only setting it once, so the quote can't be converted twice.
+	filter := bson.M{"_id": objID, "order": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"order": orderObjID, "updated_at": time.Now()}}
+
+	if err := UpdateOne[quote.Quote](ctx, r.quotesColl, filter, update); err != nil {
+		return nil, err
+	}
+
+	return r.GetQuoteByID(id, options...)
+}
+
+func (r *repository) quoteOptsToPopulateOpts(opts *quote.RetrieveOpts) []populateOpts {
+	return []populateOpts{{
+		include:      opts.PopulateClient,
+		from:         clientsCollectionName,
+		foreignField: "_id",
+		localField:   "client",
+		as:           "populated_client",
+	}}
+}
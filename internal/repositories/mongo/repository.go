@@ -18,13 +18,20 @@ import (
 )
 
 const (
-	usersCollectionName     = "users"
-	clientsCollectionName   = "clients"
-	countersCollectionName  = "counters"
-	productsCollectionName  = "products"
-	ordersCollectionName    = "orders"
-	materialsCollectionName = "materials"
-	suppliersCollectionName = "suppliers"
+	usersCollectionName        = "users"
+	clientsCollectionName      = "clients"
+	mergesCollectionName       = "client_merges"
+	countersCollectionName     = "counters"
+	productsCollectionName     = "products"
+	ordersCollectionName       = "orders"
+	materialsCollectionName    = "materials"
+	hidesCollectionName        = "material_hides"
+	suppliersCollectionName    = "suppliers"
+	costingCollectionName      = "costing_settings"
+	stockCollectionName        = "stock_movements"
+	reservationsCollectionName = "stock_reservations"
+	purchasesCollectionName    = "purchase_orders"
+	quotesCollectionName       = "quotes"
 )
 
 type repository struct {
@@ -32,13 +39,20 @@ type repository struct {
 	timeout time.Duration
 	db      *mongo.Database
 
-	usersColl     *mongo.Collection
-	clientsColl   *mongo.Collection
-	countersColl  *mongo.Collection
-	productsColl  *mongo.Collection
-	ordersColl    *mongo.Collection
-	materialsColl *mongo.Collection
-	suppliersColl *mongo.Collection
+	usersColl        *mongo.Collection
+	clientsColl      *mongo.Collection
+	mergesColl       *mongo.Collection
+	countersColl     *mongo.Collection
+	productsColl     *mongo.Collection
+	ordersColl       *mongo.Collection
+	materialsColl    *mongo.Collection
+	hidesColl        *mongo.Collection
+	suppliersColl    *mongo.Collection
+	costingColl      *mongo.Collection
+	stockColl        *mongo.Collection
+	reservationsColl *mongo.Collection
+	purchasesColl    *mongo.Collection
+	quotesColl       *mongo.Collection
 }
 
 func (r *repository) newCtx() (context.Context, context.CancelFunc) {
@@ -82,15 +96,34 @@ func NewRepository(mongoURL, dbName string, mongoTimeout int) (r.Repository, err
 
 	repo.clientsColl = repo.db.Collection(clientsCollectionName)
 	createIndex(repo.clientsColl, mongo.IndexModel{Keys: bson.D{{Key: "name", Value: 1}}, Options: options.Index().SetUnique(true)})
+	createIndex(repo.clientsColl, mongo.IndexModel{Keys: bson.D{{Key: "user", Value: 1}}, Options: options.Index().SetUnique(true).SetSparse(true)})
+
+	repo.mergesColl = repo.db.Collection(mergesCollectionName)
+	createIndex(repo.mergesColl, mongo.IndexModel{Keys: bson.D{{Key: "survivor", Value: 1}, {Key: "created_at", Value: -1}}})
 
 	repo.materialsColl = repo.db.Collection(materialsCollectionName)
 	createIndex(repo.materialsColl, mongo.IndexModel{Keys: bson.D{{Key: "name", Value: 1}}, Options: options.Index().SetUnique(true)})
 
+	repo.hidesColl = repo.db.Collection(hidesCollectionName)
+	createIndex(repo.hidesColl, mongo.IndexModel{Keys: bson.D{{Key: "material", Value: 1}, {Key: "created_at", Value: -1}}})
+
+	repo.stockColl = repo.db.Collection(stockCollectionName)
+	createIndex(repo.stockColl, mongo.IndexModel{Keys: bson.D{{Key: "material", Value: 1}, {Key: "created_at", Value: -1}}})
+
+	repo.reservationsColl = repo.db.Collection(reservationsCollectionName)
+	createIndex(repo.reservationsColl, mongo.IndexModel{Keys: bson.D{{Key: "order", Value: 1}, {Key: "item", Value: 1}, {Key: "status", Value: 1}}})
+
 	repo.suppliersColl = repo.db.Collection(suppliersCollectionName)
 	createIndex(repo.suppliersColl, mongo.IndexModel{Keys: bson.D{{Key: "name", Value: 1}}, Options: options.Index().SetUnique(true)})
+	createIndex(repo.suppliersColl, mongo.IndexModel{Keys: bson.D{{Key: "price_list.material_id", Value: 1}}})
+
+	repo.purchasesColl = repo.db.Collection(purchasesCollectionName)
+	createIndex(repo.purchasesColl, mongo.IndexModel{Keys: bson.D{{Key: "supplier", Value: 1}, {Key: "created_at", Value: -1}}})
 
 	repo.countersColl = repo.db.Collection(countersCollectionName)
 
+	repo.costingColl = repo.db.Collection(costingCollectionName)
+
 	repo.productsColl = repo.db.Collection(productsCollectionName)
 	createIndex(repo.productsColl, mongo.IndexModel{Keys: bson.D{{Key: "variants._id", Value: 1}}, Options: options.Index().SetUnique(true)})
 
@@ -99,5 +132,9 @@ func NewRepository(mongoURL, dbName string, mongoTimeout int) (r.Repository, err
 	createIndex(repo.ordersColl, mongo.IndexModel{Keys: bson.D{{Key: "client", Value: 1}}})
 	createIndex(repo.ordersColl, mongo.IndexModel{Keys: bson.D{{Key: "items._id", Value: 1}}, Options: options.Index().SetUnique(true)})
 
+	repo.quotesColl = repo.db.Collection(quotesCollectionName)
+	createIndex(repo.quotesColl, mongo.IndexModel{Keys: bson.D{{Key: "status", Value: 1}, {Key: "expires_at", Value: 1}}})
+	createIndex(repo.quotesColl, mongo.IndexModel{Keys: bson.D{{Key: "created_at", Value: -1}}})
+
 	return repo, nil
 }
@@ -0,0 +1,182 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/omareloui/odinls/internal/application/core/material"
+	"github.com/omareloui/odinls/internal/application/core/stock"
+	"github.com/omareloui/odinls/internal/errs"
+	"github.com/omareloui/odinls/internal/logger"
+	"github.com/omareloui/odinls/internal/repositories/mongo/bsonutils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+func (r *repository) GetMovementsByMaterialID(materialID string) ([]stock.Movement, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	matObjID, err := primitive.ObjectIDFromHex(materialID)
+	if err != nil {
+		return nil, errs.ErrInvalidID
+	}
+
+	return PopulateAggregation[stock.Movement](ctx, r.stockColl,
+		bson.A{
+			bson.M{"$match": bson.M{"material": matObjID}},
+			bson.M{"$sort": bson.M{"created_at": -1}},
+		})
+}
+
+func (r *repository) AddMovement(mv *stock.Movement) (*stock.Movement, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	l := logger.FromCtx(ctx)
+
+	matObjID, err := primitive.ObjectIDFromHex(mv.MaterialID)
+	if err != nil {
+		return nil, errs.ErrInvalidID
+	}
+
+	// The balance is updated in the same operation that checks it, so
+	// concurrent movements can't take the stock below zero.
+	filter := bson.M{"_id": matObjID}
+	if mv.Quantity < 0 {
+		filter["quantity_on_hand"] = bson.M{"$gte": -mv.Quantity}
+	}
+	update := bson.M{
+		"$inc": bson.M{"quantity_on_hand": mv.Quantity},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+
+	mat := new(material.Material)
+	err = r.materialsColl.FindOneAndUpdate(ctx, filter, update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(mat)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errs.ErrInsufficientStock
+		}
+		l.Error("error updating the material's quantity on hand", zap.Error(err), zap.Any("movement", mv))
+		return nil, err
+	}
+
+	mv.BalanceAfter = mat.QuantityOnHand
+
+	created, err := insertMovement(ctx, r.stockColl, mv)
+	if err != nil {
+		// Revert the balance to keep it matching the ledger.
+		revert := bson.M{"$inc": bson.M{"quantity_on_hand": -mv.Quantity}}
+		if _, rerr := r.materialsColl.UpdateByID(ctx, matObjID, revert); rerr != nil {
+			l.Error("error reverting the material's quantity on hand", zap.Error(rerr), zap.Any("movement", mv))
+		}
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (r *repository) InsertMovement(mv *stock.Movement) (*stock.Movement, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	return insertMovement(ctx, r.stockColl, mv)
+}
+
+func insertMovement(ctx context.Context, coll *mongo.Collection, mv *stock.Movement) (*stock.Movement, error) {
+	return InsertStruct(ctx, coll, mv,
+		bsonutils.WithObjectID("material"),
+		bsonutils.WithOptionalObjectID("hide"),
+		bsonutils.WithOptionalObjectID("user"),
+	)
+}
+
+func (r *repository) GetActiveReservations(orderID, itemID string) ([]stock.Reservation, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	ordObjID, err := primitive.ObjectIDFromHex(orderID)
+	if err != nil {
+		return nil, errs.ErrInvalidID
+	}
+
+	filter := bson.M{"order": ordObjID, "status": stock.ReservationActive}
+	if itemID != "" {
+		itemObjID, err := primitive.ObjectIDFromHex(itemID)
+		if err != nil {
+			return nil, errs.ErrInvalidID
+		}
+		filter["item"] = itemObjID
+	}
+
+	return Get[stock.Reservation](ctx, r.reservationsColl, &filter)
+}
+
+func (r *repository) AddReservation(res *stock.Reservation) (*stock.Reservation, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	l := logger.FromCtx(ctx)
+
+	matObjID, err := primitive.ObjectIDFromHex(res.MaterialID)
+	if err != nil {
+		return nil, errs.ErrInvalidID
+	}
+
+	if err := r.incReserved(ctx, matObjID, res.Quantity); err != nil {
+		return nil, err
+	}
+
+	created, err := InsertStruct(ctx, r.reservationsColl, res,
+		bsonutils.WithObjectID("order"),
+		bsonutils.WithObjectID("item"),
+		bsonutils.WithObjectID("material"),
+	)
+	if err != nil {
+		if rerr := r.incReserved(ctx, matObjID, -res.Quantity); rerr != nil {
+			l.Error("error reverting the material's reserved quantity", zap.Error(rerr), zap.Any("reservation", res))
+		}
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (r *repository) CloseReservation(res *stock.Reservation, status stock.ReservationStatusEnum) error {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	resObjID, err := primitive.ObjectIDFromHex(res.ID)
+	if err != nil {
+		return errs.ErrInvalidID
+	}
+	matObjID, err := primitive.ObjectIDFromHex(res.MaterialID)
+	if err != nil {
+		return errs.ErrInvalidID
+	}
+
+	// Only an active reservation can be closed, so it's never taken out of
+	// the reserved quantity twice.
+	err = UpdateOne[stock.Reservation](ctx, r.reservationsColl,
+		bson.M{"_id": resObjID, "status": stock.ReservationActive},
+		bson.M{"$set": bson.M{"status": status, "updated_at": time.Now()}})
+	if err != nil {
+		return err
+	}
+
+	return r.incReserved(ctx, matObjID, -res.Quantity)
+}
+
+func (r *repository) incReserved(ctx context.Context, matObjID primitive.ObjectID, quantity float64) error {
+	return UpdateOne[material.Material](ctx, r.materialsColl,
+		bson.M{"_id": matObjID},
+		bson.M{
+			"$inc": bson.M{"quantity_reserved": quantity},
+			"$set": bson.M{"updated_at": time.Now()},
+		})
+}
@@ -2,6 +2,7 @@ package mongo
 
 import (
 	"github.com/omareloui/odinls/internal/application/core/supplier"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 func (r *repository) GetSuppliers() ([]supplier.Supplier, error) {
@@ -31,3 +32,11 @@ func (r *repository) UpdateSupplierByID(id string, sup *supplier.Supplier) (*sup
 
 	return UpdateStructByID(ctx, r.suppliersColl, id, sup)
 }
+
+func (r *repository) GetSuppliersByMaterialID(materialID string) ([]supplier.Supplier, error) {
+	ctx, cancel := r.newCtx()
+	defer cancel()
+
+	filter := bson.M{"price_list.material_id": materialID}
+	return Get[supplier.Supplier](ctx, r.suppliersColl, &filter)
+}
@@ -195,6 +195,30 @@ func UpdateOne[T any](ctx context.Context, coll *mongo.Collection, filter bson.M
 	return nil
 }
 
+func DeleteByID(ctx context.Context, coll *mongo.Collection, id string) error {
+	l := logger.FromCtx(ctx)
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		l.Error("error getting id from hex", zap.String("collection", coll.Name()), zap.Error(err), zap.String("hex", id))
+		return errs.ErrInvalidID
+	}
+
+	res, err := coll.DeleteOne(ctx, bson.M{"_id": objID})
+	if err != nil {
+		l.Error("error deleting document", zap.String("collection", coll.Name()), zap.Error(err), zap.String("id", id))
+		return err
+	}
+	if res.DeletedCount == 0 {
+		l.Info("no document found to delete", zap.String("collection", coll.Name()), zap.String("id", id))
+		return errs.ErrDocumentNotFound
+	}
+
+	l.Info("deleted the document", zap.String("collection", coll.Name()), zap.String("id", id))
+
+	return nil
+}
+
 type populateOpts struct {
 	include      bool
 	isMany       bool
@@ -255,6 +279,9 @@ func PopulateAggregationByID[T any](ctx context.Context, coll *mongo.Collection,
 	if err != nil {
 		return nil, err
 	}
+	if len(docs) == 0 {
+		return nil, errs.ErrDocumentNotFound
+	}
 
 	return &docs[0], nil
 }
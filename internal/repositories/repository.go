@@ -2,20 +2,30 @@ package repository
 
 import (
 	"github.com/omareloui/odinls/internal/application/core/client"
+	"github.com/omareloui/odinls/internal/application/core/costing"
 	"github.com/omareloui/odinls/internal/application/core/counter"
 	"github.com/omareloui/odinls/internal/application/core/material"
 	"github.com/omareloui/odinls/internal/application/core/order"
 	"github.com/omareloui/odinls/internal/application/core/product"
+	"github.com/omareloui/odinls/internal/application/core/purchase"
+	"github.com/omareloui/odinls/internal/application/core/quote"
+	"github.com/omareloui/odinls/internal/application/core/reorder"
+	"github.com/omareloui/odinls/internal/application/core/stock"
 	"github.com/omareloui/odinls/internal/application/core/supplier"
 	"github.com/omareloui/odinls/internal/application/core/user"
 )
 
 type Repository interface {
 	client.ClientRepository
+	costing.CostingRepository
 	counter.CounterRepository
 	material.MaterialRepository
 	order.OrderRepository
 	product.ProductRepository
+	purchase.PurchaseRepository
+	quote.QuoteRepository
+	reorder.ReorderRepository
+	stock.StockRepository
 	supplier.SupplierRepository
 	user.UserRepository
 }